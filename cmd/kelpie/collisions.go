@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamconnelly/kelpie/parser"
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+// MethodCollisionStrategy defines what happens when the setup function generated for a method
+// collides with another part of the mock's API.
+type MethodCollisionStrategy string
+
+const (
+	// MethodCollisionsRename renames any setup functions that collide with the mock's API by adding
+	// a suffix to them.
+	MethodCollisionsRename MethodCollisionStrategy = "rename"
+
+	// MethodCollisionsError fails generation if any setup functions collide with the mock's API.
+	MethodCollisionsError MethodCollisionStrategy = "error"
+)
+
+// defaultCollisionSuffix is added to the names of setup functions that collide with the mock's API
+// when no suffix has been configured.
+const defaultCollisionSuffix = "Method"
+
+// mockAPINames contains the names used by the API of the generated mock. The mock embeds mocking.Mock,
+// so a method called Setup or Reset would have a setup function that's easily confused with the mock's
+// own methods.
+var mockAPINames = []string{"Call", "Called", "Expectations", "Instance", "MethodCalls", "Reset", "Setup"}
+
+// instanceMockFieldName is the name of the field the mock's instance uses to reference the mock.
+const instanceMockFieldName = "mock"
+
+// helperTypeSuffixes contains the suffixes used for the types generated for each method.
+var helperTypeSuffixes = []string{"MethodMatcher", "Times", "Action"}
+
+// withSetupFunctionNames chooses the names of the setup functions generated for each method, detecting
+// any that would collide with the mock's API or with the types generated by the mock. Collisions are
+// either renamed, or reported as an error, depending on the generation options.
+func (d mockTemplateData) withSetupFunctionNames(options MockGenerationOptions) (mockTemplateData, error) {
+	// Names that can't be used by any of the setup functions.
+	reserved := map[string]bool{
+		d.MockTypeName():     true,
+		d.ConstructorName():  true,
+		d.InstanceTypeName(): true,
+	}
+
+	if d.StructType != "" {
+		reserved[d.InterfaceName()] = true
+	}
+
+	// Mocks generated inside the package being mocked prefix their setup functions, so they can't be
+	// confused with the mock's methods. Function types are mocked using a method called Call, and
+	// renaming it would make them more confusing to use rather than less.
+	if d.Prefix == "" && d.FunctionType == "" {
+		for _, name := range mockAPINames {
+			reserved[name] = true
+		}
+	}
+
+	// The types generated for each method are reserved too, since they're declared in the same scope as
+	// the setup functions.
+	for _, method := range d.Methods {
+		for _, name := range helperTypeNames(d.SetupFunctionName(method.Name)) {
+			reserved[name] = true
+		}
+	}
+
+	var collisions []string
+	for _, method := range d.Methods {
+		if name := d.SetupFunctionName(method.Name); reserved[name] {
+			collisions = append(collisions, method.Name)
+		}
+	}
+
+	if len(collisions) == 0 {
+		return d, nil
+	}
+
+	if options.MethodCollisions == MethodCollisionsError {
+		return d, fmt.Errorf(
+			"could not generate a mock for '%s' because the setup functions for the following methods collide with the mock's API: %s. Set the 'methodCollisions' generation option to 'rename' to rename them",
+			d.Name, strings.Join(collisions, ", "))
+	}
+
+	if options.MethodCollisions != "" && options.MethodCollisions != MethodCollisionsRename {
+		return d, fmt.Errorf("unknown method collision strategy '%s'. The supported strategies are 'rename' and 'error'", options.MethodCollisions)
+	}
+
+	suffix := options.CollisionSuffix
+	if suffix == "" {
+		suffix = defaultCollisionSuffix
+	}
+
+	// The setup functions for methods that don't collide keep their names, so none of the renamed
+	// functions can use them.
+	used := map[string]bool{}
+	for _, method := range d.Methods {
+		if !slices.Contains(collisions, func(c string) bool { return c == method.Name }) {
+			used[d.SetupFunctionName(method.Name)] = true
+		}
+	}
+
+	isAvailable := func(name string) bool {
+		if reserved[name] || used[name] {
+			return false
+		}
+
+		return !slices.Contains(helperTypeNames(name), func(helper string) bool { return reserved[helper] || used[helper] })
+	}
+
+	setupFunctionNames := map[string]string{}
+	for _, method := range collisions {
+		name := d.SetupFunctionName(method) + suffix
+		for index := 2; !isAvailable(name); index++ {
+			name = d.SetupFunctionName(method) + suffix + strconv.Itoa(index)
+		}
+
+		used[name] = true
+		setupFunctionNames[method] = name
+	}
+
+	d.setupFunctionNames = setupFunctionNames
+
+	return d, nil
+}
+
+// helperTypeNames returns the names of the types generated for a method with the specified setup function.
+func helperTypeNames(setupFunctionName string) []string {
+	return slices.Map(helperTypeSuffixes, func(suffix string) string { return unexport(setupFunctionName) + suffix })
+}
+
+// InstanceMockFieldName returns the name of the field the mock's instance uses to reference the mock.
+// The instance has a method for each mocked method, so the field is renamed if one of the methods is
+// called mock, which can happen when mocking an unexported interface inside its package.
+func (d mockTemplateData) InstanceMockFieldName() string {
+	name := instanceMockFieldName
+	for index := 2; slices.Contains(d.Methods, func(m parser.MethodDefinition) bool { return m.Name == name }); index++ {
+		name = instanceMockFieldName + strconv.Itoa(index)
+	}
+
+	return name
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/parser"
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+type CollisionsTests struct {
+	suite.Suite
+}
+
+func (t *CollisionsTests) Test_WithSetupFunctionNames_RenamesCollidingMethodsByDefault() {
+	// Arrange
+	data := newTemplateData("Lifecycle", "Setup", "Start")
+
+	// Act
+	result, err := data.withSetupFunctionNames(MockGenerationOptions{})
+
+	// Assert
+	t.NoError(err)
+	t.Equal("SetupMethod", result.SetupFunctionName("Setup"))
+	t.Equal("Start", result.SetupFunctionName("Start"))
+}
+
+func (t *CollisionsTests) Test_WithSetupFunctionNames_ReturnsErrorListingCollisionsWhenStrategyIsError() {
+	// Arrange
+	data := newTemplateData("Lifecycle", "Setup", "Start", "Reset")
+
+	// Act
+	_, err := data.withSetupFunctionNames(MockGenerationOptions{MethodCollisions: MethodCollisionsError})
+
+	// Assert
+	t.EqualError(err, "could not generate a mock for 'Lifecycle' because the setup functions for the following methods collide with the mock's API: Setup, Reset. Set the 'methodCollisions' generation option to 'rename' to rename them")
+}
+
+func (t *CollisionsTests) Test_WithSetupFunctionNames_RejectsUnknownStrategies() {
+	// Arrange
+	data := newTemplateData("Lifecycle", "Setup")
+
+	// Act
+	_, err := data.withSetupFunctionNames(MockGenerationOptions{MethodCollisions: "ignore"})
+
+	// Assert
+	t.EqualError(err, "unknown method collision strategy 'ignore'. The supported strategies are 'rename' and 'error'")
+}
+
+func (t *CollisionsTests) Test_WithSetupFunctionNames_UsesCustomSuffix() {
+	// Arrange
+	data := newTemplateData("Lifecycle", "Setup", "Reset")
+
+	// Act
+	result, err := data.withSetupFunctionNames(MockGenerationOptions{CollisionSuffix: "Fn"})
+
+	// Assert
+	t.NoError(err)
+	t.Equal("SetupFn", result.SetupFunctionName("Setup"))
+	t.Equal("ResetFn", result.SetupFunctionName("Reset"))
+}
+
+func (t *CollisionsTests) Test_WithSetupFunctionNames_AddsNumberWhenSuffixedNameCollides() {
+	// Arrange
+	data := newTemplateData("Lifecycle", "Setup", "SetupMethod")
+
+	// Act
+	result, err := data.withSetupFunctionNames(MockGenerationOptions{})
+
+	// Assert
+	t.NoError(err)
+	t.Equal("SetupMethod2", result.SetupFunctionName("Setup"))
+	t.Equal("SetupMethod", result.SetupFunctionName("SetupMethod"))
+}
+
+func (t *CollisionsTests) Test_WithSetupFunctionNames_DoesNotRenameMethodsOfInPackageMocks() {
+	// Arrange
+	data := newTemplateData("Lifecycle", "Setup")
+	data.Prefix = "lifecycle"
+
+	// Act
+	result, err := data.withSetupFunctionNames(MockGenerationOptions{MethodCollisions: MethodCollisionsError})
+
+	// Assert
+	t.NoError(err)
+	t.Equal("lifecycleSetup", result.SetupFunctionName("Setup"))
+}
+
+func (t *CollisionsTests) Test_InstanceMockFieldName_RenamesFieldWhenMethodIsCalledMock() {
+	// Arrange
+	data := newTemplateData("doubleFactory", "mock", "mock2")
+
+	// Act
+	name := data.InstanceMockFieldName()
+
+	// Assert
+	t.Equal("mock3", name)
+}
+
+func (t *CollisionsTests) Test_InstanceMockFieldName_UsesMockWhenThereIsNoCollision() {
+	// Arrange
+	data := newTemplateData("doubleFactory", "create")
+
+	// Act
+	name := data.InstanceMockFieldName()
+
+	// Assert
+	t.Equal("mock", name)
+}
+
+func newTemplateData(name string, methods ...string) mockTemplateData {
+	return mockTemplateData{
+		MockedInterface: parser.MockedInterface{
+			Name:     name,
+			FullName: name,
+			Methods:  slices.Map(methods, func(method string) parser.MethodDefinition { return parser.MethodDefinition{Name: method} }),
+		},
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	suite.Run(t, new(CollisionsTests))
+}
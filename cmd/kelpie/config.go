@@ -1,5 +1,11 @@
 package main
 
+import (
+	"fmt"
+
+	"github.com/adamconnelly/kelpie/parser"
+)
+
 // ConfigVersion defines the version of Kelpie's config file.
 type ConfigVersion string
 
@@ -28,21 +34,120 @@ type PackageConfig struct {
 	// Output directory is the directory to output generated mocks for this package. Defaults
 	// to a folder called "mocks" in the package directory if not specified.
 	OutputDirectory string `yaml:"directory"`
+
+	// Variant selects which variant of the package to parse. Can be "test" (the default), which
+	// includes the package's _test.go files, "package" which excludes any test files, or "xtest"
+	// which parses the package's external test package (for example users_test). Mocks for
+	// interfaces in an external test package are always generated in _test.go files inside it.
+	Variant parser.PackageVariant `yaml:"variant"`
 }
 
 // MockConfig is configuration of an individual mock.
 type MockConfig struct {
 	// InterfaceName is the name of the interface to mock, for example "Maths" or "SomeService.SomeRepository".
+	// A specific instantiation of a generic interface can be mocked by including the type arguments,
+	// for example "Cache[string, users.User]". Function types can also be mocked by specifying the name of the
+	// function type, for example "Authorizer". Specifying a struct type generates an interface containing the
+	// struct's exported methods, along with a mock for that interface.
 	InterfaceName string `yaml:"interface"`
 
+	// Pattern is a glob pattern used to mock any interfaces with matching names, for example "*Repository".
+	// A "*" matches any sequence of characters and a "?" matches any single character, but neither match
+	// the "." separating the parts of a nested interface's name, so "*" mocks every interface declared in
+	// the package. Patterns only match interfaces - struct and function types must be specified using
+	// InterfaceName.
+	Pattern string `yaml:"pattern"`
+
+	// Regex is a regular expression used to mock any interfaces with matching names. The expression
+	// must match the entire name, for example "(User|Account)Service". Like patterns, regular expressions
+	// only match interfaces.
+	Regex string `yaml:"regex"`
+
+	// Exclude contains glob patterns for interfaces that shouldn't be mocked even though they match
+	// Pattern or Regex, for example "internal*".
+	Exclude []string `yaml:"exclude"`
+
+	// Combine contains a list of interfaces to combine into a single mock. The mock implements all
+	// the methods of the combined interfaces, and InterfaceName is used as the name of the mock rather
+	// than referencing an interface in the package.
+	Combine []InterfaceReference `yaml:"combine"`
+
 	// GenerationOptions allows generation of the mock to be customized.
 	GenerationOptions MockGenerationOptions `yaml:"generation"`
 }
 
+// InterfaceReference references an interface that is combined into a composite mock.
+type InterfaceReference struct {
+	// PackageName is the full path of the package containing the interface, for example "io". Defaults
+	// to the package the mock is configured in.
+	PackageName string `yaml:"package"`
+
+	// InterfaceName is the name of the interface, for example "Reader".
+	InterfaceName string `yaml:"interface"`
+}
+
 // MockGenerationOptions allows generation of the mock to be customized.
 type MockGenerationOptions struct {
 	// PackageName is the name of the generated package for the mock. Defaults to the lowercased
 	// interface name. For example an interface called EmailSender would generate a package
-	// called emailsender.
+	// called emailsender. This is ignored when a pattern or regular expression is used, since
+	// each matching interface needs its own package.
 	PackageName string `yaml:"package"`
+
+	// InPackage generates the mock inside the package being mocked rather than in a separate mock
+	// package. This allows unexported interfaces, and interfaces that reference unexported types, to
+	// be mocked. The mock's types and functions are prefixed with the interface name to avoid clashes,
+	// for example an interface called emailSender generates a newEmailSenderMock function.
+	InPackage bool `yaml:"inPackage"`
+
+	// TestFile writes an in-package mock to a _test.go file, so that it is only included in the
+	// package's tests. Only used when InPackage is true.
+	TestFile bool `yaml:"testFile"`
+
+	// MethodCollisions controls what happens when the setup function for one of the interface's
+	// methods collides with the mock's API, for example a method called Reset or Mock. Can be "rename"
+	// (the default), which adds CollisionSuffix to the setup function's name, or "error", which fails
+	// generation instead.
+	MethodCollisions MethodCollisionStrategy `yaml:"methodCollisions"`
+
+	// CollisionSuffix is added to the names of setup functions that are renamed to avoid collisions.
+	// Defaults to "Method", so the setup function for a method called Reset is called ResetMethod.
+	CollisionSuffix string `yaml:"collisionSuffix"`
+}
+
+// Filter returns the filter used to select the interfaces included by this mock.
+func (c MockConfig) Filter() (parser.InterfaceFilter, error) {
+	var filter parser.InterfaceFilter
+	switch {
+	case c.InterfaceName != "" && c.Pattern == "" && c.Regex == "":
+		filter = &parser.IncludingInterfaceFilter{InterfacesToInclude: []string{c.InterfaceName}}
+	case c.Pattern != "" && c.InterfaceName == "" && c.Regex == "":
+		filter = parser.NewGlobInterfaceFilter(c.Pattern)
+	case c.Regex != "" && c.InterfaceName == "" && c.Pattern == "":
+		regexFilter, err := parser.NewRegexInterfaceFilter(c.Regex)
+		if err != nil {
+			return nil, err
+		}
+
+		filter = regexFilter
+	default:
+		return nil, fmt.Errorf("exactly one of 'interface', 'pattern' or 'regex' must be specified for each mock, but got interface '%s', pattern '%s' and regex '%s'", c.InterfaceName, c.Pattern, c.Regex)
+	}
+
+	if len(c.Exclude) > 0 {
+		filter = &parser.ExcludingInterfaceFilter{Filter: filter, Exclude: parser.NewGlobInterfaceFilter(c.Exclude...)}
+	}
+
+	return filter, nil
+}
+
+// IsPattern returns true if the mock matches interfaces using a pattern or regular expression rather
+// than by name.
+func (c MockConfig) IsPattern() bool {
+	return c.Pattern != "" || c.Regex != ""
+}
+
+// hasPackageName returns true if the name of the mock's package has been explicitly configured.
+func (c MockConfig) hasPackageName() bool {
+	return c.GenerationOptions.PackageName != "" && !c.IsPattern()
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"regexp"
+	"slices"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/adamconnelly/kelpie/parser"
+)
+
+// templateIdentifiers contains the receivers, local variables, packages and builtins referenced by
+// the parts of the mock template that also use the interface's parameter and result names. Parameters
+// and results with any of these names would clash with them, causing the mock not to compile.
+var templateIdentifiers = []string{
+	"any", "append", "arg", "expectation", "kelpie", "m", "make", "matcher", "matchers", "mocking", "nil",
+	"observe", "ok", "panic", "result",
+}
+
+// resultTemplateIdentifiers contains any additional identifiers that clash with result names. Results
+// are used as the parameters of the Return functions, whose receivers are called t.
+var resultTemplateIdentifiers = []string{"t"}
+
+// matcherTypeParamPattern matches the type parameters generated for the parameters of setup functions.
+var matcherTypeParamPattern = regexp.MustCompile(`^P[0-9]+$`)
+
+// withHygienicIdentifiers renames any parameters and results that clash with identifiers used by the
+// generated mock, so that any valid interface produces a mock that compiles. This includes the
+// template's own variables, any packages and types referenced by the mock, and the names generated
+// for unnamed results.
+func (d mockTemplateData) withHygienicIdentifiers() mockTemplateData {
+	reserved := map[string]bool{}
+	for _, name := range templateIdentifiers {
+		reserved[name] = true
+	}
+
+	for _, typeParam := range d.TypeParameters {
+		reserved[typeParam.Name] = true
+		addTypeIdentifiers(reserved, typeParam.Constraint)
+	}
+
+	for _, method := range d.Methods {
+		for _, param := range method.Parameters {
+			addTypeIdentifiers(reserved, param.Type)
+		}
+
+		for _, result := range method.Results {
+			addTypeIdentifiers(reserved, result.Type)
+		}
+	}
+
+	methods := make([]parser.MethodDefinition, len(d.Methods))
+	for index, method := range d.Methods {
+		methods[index] = d.hygienicMethod(method, reserved)
+	}
+
+	d.Methods = methods
+
+	return d
+}
+
+func (d mockTemplateData) hygienicMethod(method parser.MethodDefinition, reserved map[string]bool) parser.MethodDefinition {
+	// The types generated for the method are also referenced in the bodies of its functions.
+	setupName := unexport(d.SetupFunctionName(method.Name))
+	isReserved := func(name string) bool {
+		return reserved[name] || matcherTypeParamPattern.MatchString(name) ||
+			name == setupName+"MethodMatcher" || name == setupName+"Action"
+	}
+
+	// Names that are already in use by the method's other parameters and results can't be used
+	// when renaming.
+	used := map[string]bool{}
+	for _, param := range method.Parameters {
+		used[param.Name] = true
+	}
+
+	for _, result := range method.Results {
+		used[result.Name] = true
+	}
+
+	isReservedResult := func(name string) bool {
+		return isReserved(name) || slices.Contains(resultTemplateIdentifiers, name)
+	}
+
+	rename := func(name string) string {
+		// Names already ending in a digit, like r0 or P0, need a separator so that adding a suffix
+		// doesn't just produce another name of the same form.
+		separator := ""
+		if lastRune, _ := utf8.DecodeLastRuneInString(name); unicode.IsDigit(lastRune) {
+			separator = "_"
+		}
+
+		for suffix := 1; ; suffix++ {
+			candidate := name + separator + strconv.Itoa(suffix)
+			if !isReservedResult(candidate) && !used[candidate] {
+				used[candidate] = true
+				return candidate
+			}
+		}
+	}
+
+	parameters := make([]parser.ParameterDefinition, len(method.Parameters))
+	for index, param := range method.Parameters {
+		if isReserved(param.Name) {
+			param.Name = rename(param.Name)
+		}
+
+		parameters[index] = param
+	}
+
+	results := make([]parser.ResultDefinition, len(method.Results))
+	for index, result := range method.Results {
+		switch {
+		case result.Name == "" || result.Name == "_":
+			// Unnamed results are given names so that they can be assigned to in the mock, which
+			// could otherwise clash with the method's parameters.
+			result.Name = "r" + strconv.Itoa(index)
+			if isReservedResult(result.Name) || used[result.Name] {
+				result.Name = rename(result.Name)
+			} else {
+				used[result.Name] = true
+			}
+		case isReservedResult(result.Name):
+			result.Name = rename(result.Name)
+		}
+
+		results[index] = result
+	}
+
+	method.Parameters = parameters
+	method.Results = results
+
+	return method
+}
+
+// addTypeIdentifiers adds all the identifiers referenced by a type to names, including the names of
+// any packages and types.
+func addTypeIdentifiers(names map[string]bool, typeString string) {
+	expression, err := goparser.ParseExpr(typeString)
+	if err != nil {
+		return
+	}
+
+	ast.Inspect(expression, func(n ast.Node) bool {
+		if identifier, ok := n.(*ast.Ident); ok {
+			names[identifier.Name] = true
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/parser"
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+type IdentifiersTests struct {
+	suite.Suite
+}
+
+func (t *IdentifiersTests) Test_WithHygienicIdentifiers_RenamesParametersNamedAfterImportedPackages() {
+	// Arrange
+	data := newMethodTemplateData(parser.MethodDefinition{
+		Name: "Send",
+		Parameters: []parser.ParameterDefinition{
+			{Name: "context", Type: "context.Context"},
+			{Name: "message", Type: "string"},
+		},
+	})
+
+	// Act
+	result := data.withHygienicIdentifiers()
+
+	// Assert
+	t.Equal([]string{"context1", "message"}, parameterNames(result.Methods[0]))
+}
+
+func (t *IdentifiersTests) Test_WithHygienicIdentifiers_RenamesParametersNamedAfterPackagesUsedByTheMock() {
+	// Arrange
+	data := newMethodTemplateData(parser.MethodDefinition{
+		Name: "Send",
+		Parameters: []parser.ParameterDefinition{
+			{Name: "mocking", Type: "bool"},
+			{Name: "kelpie", Type: "string"},
+		},
+	})
+
+	// Act
+	result := data.withHygienicIdentifiers()
+
+	// Assert
+	t.Equal([]string{"mocking1", "kelpie1"}, parameterNames(result.Methods[0]))
+}
+
+func (t *IdentifiersTests) Test_WithHygienicIdentifiers_RenamesParametersNamedAfterTypesInTheSignature() {
+	// Arrange
+	data := newMethodTemplateData(parser.MethodDefinition{
+		Name: "Save",
+		Parameters: []parser.ParameterDefinition{
+			{Name: "Config", Type: "Config"},
+			{Name: "user", Type: "*User"},
+		},
+		Results: []parser.ResultDefinition{
+			{Name: "User", Type: "bool"},
+		},
+	})
+
+	// Act
+	result := data.withHygienicIdentifiers()
+
+	// Assert
+	t.Equal([]string{"Config1", "user"}, parameterNames(result.Methods[0]))
+	t.Equal("User1", result.Methods[0].Results[0].Name)
+}
+
+func (t *IdentifiersTests) Test_WithHygienicIdentifiers_SkipsNamesThatAreAlreadyTaken() {
+	// Arrange
+	data := newMethodTemplateData(parser.MethodDefinition{
+		Name: "Send",
+		Parameters: []parser.ParameterDefinition{
+			{Name: "context", Type: "context.Context"},
+			{Name: "context1", Type: "string"},
+			{Name: "r0", Type: "int"},
+		},
+		Results: []parser.ResultDefinition{
+			{Type: "error"},
+		},
+	})
+
+	// Act
+	result := data.withHygienicIdentifiers()
+
+	// Assert
+	t.Equal([]string{"context2", "context1", "r0"}, parameterNames(result.Methods[0]))
+	t.Equal("r0_1", result.Methods[0].Results[0].Name)
+}
+
+func (t *IdentifiersTests) Test_WithHygienicIdentifiers_RenamesParametersNamedLikeMatcherTypeParameters() {
+	// Arrange
+	data := newMethodTemplateData(parser.MethodDefinition{
+		Name: "Send",
+		Parameters: []parser.ParameterDefinition{
+			{Name: "P0", Type: "string"},
+			{Name: "P0_1", Type: "string"},
+		},
+	})
+
+	// Act
+	result := data.withHygienicIdentifiers()
+
+	// Assert
+	t.Equal([]string{"P0_2", "P0_1"}, parameterNames(result.Methods[0]))
+}
+
+func newMethodTemplateData(method parser.MethodDefinition) mockTemplateData {
+	return mockTemplateData{
+		MockedInterface: parser.MockedInterface{
+			Name:     "Sender",
+			FullName: "Sender",
+			Methods:  []parser.MethodDefinition{method},
+		},
+	}
+}
+
+func parameterNames(method parser.MethodDefinition) []string {
+	return slices.Map(method.Parameters, func(p parser.ParameterDefinition) string { return p.Name })
+}
+
+func TestIdentifiers(t *testing.T) {
+	suite.Run(t, new(IdentifiersTests))
+}
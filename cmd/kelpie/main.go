@@ -2,12 +2,19 @@
 package main
 
 import (
+	"bytes"
 	_ "embed"
 	"fmt"
+	"go/format"
+	"go/token"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"unicode"
 	"unicode/utf8"
@@ -16,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
+	"github.com/adamconnelly/kelpie/maps"
 	"github.com/adamconnelly/kelpie/parser"
 	"github.com/adamconnelly/kelpie/slices"
 )
@@ -24,20 +32,44 @@ import (
 var mockTemplate string
 
 type generateCmd struct {
-	ConfigFile string   `name:"config-file" short:"c" help:"The path to Kelpie's configuration file."`
-	Package    string   `name:"package" short:"p" help:"The Go package containing the interface to mock."`
-	Interfaces []string `name:"interfaces" short:"i" help:"The names of the interfaces to mock."`
-	OutputDir  string   `name:"output-dir" short:"o" default:"mocks" help:"The directory to write the mock out to."`
+	ConfigFile   string   `name:"config-file" short:"c" help:"The path to Kelpie's configuration file."`
+	Package      string   `name:"package" short:"p" help:"The Go package containing the interface to mock."`
+	Interfaces   []string `name:"interfaces" short:"i" help:"The names of the interfaces to mock. Glob patterns like '*Repository' and regular expressions wrapped in slashes like '/(User|Account)Service/' can be used to mock any matching interfaces."`
+	Exclude      []string `name:"exclude" short:"x" help:"Glob patterns for interfaces that shouldn't be mocked even though they match one of the interface patterns."`
+	OutputDir    string   `name:"output-dir" short:"o" default:"mocks" help:"The directory to write the mock out to."`
+	Force        bool     `name:"force" help:"Overwrite existing files even if they weren't generated by Kelpie."`
+	Disambiguate bool     `name:"disambiguate" help:"Automatically rename mocks that would otherwise be written to the same file, using the names of nested interfaces and the packages being mocked."`
+	Packages     []string `arg:"" optional:"" name:"packages" help:"Package patterns to search for types annotated with //kelpie:mock, for example ./..."`
 }
 
 func (g *generateCmd) Run() (err error) {
-	if g.ConfigFile != "" && (g.Package != "" || len(g.Interfaces) > 0 || g.OutputDir != "") {
+	if g.ConfigFile != "" && (g.Package != "" || len(g.Interfaces) > 0 || len(g.Exclude) > 0 || g.OutputDir != "") {
 		return errors.New("please either specify a Kelpie config file, or specify the -package, -interfaces and -output-dir options, but not both")
 	}
 
+	if len(g.Packages) > 0 && (g.ConfigFile != "" || g.Package != "" || len(g.Interfaces) > 0) {
+		return errors.New("please either specify package patterns to search for annotated types, or specify a Kelpie config file or the -package and -interfaces options, but not both")
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(err, "could not get current working directory")
+	}
+
 	var config Config
+	var loaded *parser.LoadedPackages
 
-	if g.Package == "" {
+	if len(g.Packages) > 0 {
+		config.Packages, loaded, err = g.findAnnotatedMocks(cwd)
+		if err != nil {
+			return err
+		}
+	} else if g.isGoGenerate(cwd) {
+		config.Packages, err = g.findGoGenerateMock(cwd)
+		if err != nil {
+			return err
+		}
+	} else if g.Package == "" {
 		configFile, err := g.tryOpenConfigFile(g.ConfigFile)
 		if err != nil {
 			return err
@@ -57,32 +89,227 @@ func (g *generateCmd) Run() (err error) {
 				PackageName:     g.Package,
 				OutputDirectory: g.OutputDir,
 				Mocks: slices.Map(g.Interfaces, func(interfaceName string) MockConfig {
-					return MockConfig{
-						InterfaceName: interfaceName,
-					}
+					return newMockConfig(interfaceName, g.Exclude)
 				}),
 			},
 		}
 	}
 
-	cwd, err := os.Getwd()
+	fmt.Printf("Kelpie mock generation starting - preparing to add some magic to your code-base!\n\n")
+
+	// Loading all the packages together means that any dependencies they share only need to be
+	// type-checked once, which is much quicker than loading each package separately.
+	if loaded == nil {
+		loaded, err = parser.Load(cwd, packageNames(config.Packages)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	var files []mockFile
+	for _, pkg := range config.Packages {
+		packageFiles, err := g.generatePackageMocks(loaded, pkg)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, packageFiles...)
+	}
+
+	files, err = resolveOutputPaths(files, g.Disambiguate)
 	if err != nil {
-		return errors.Wrap(err, "could not get current working directory")
+		return err
 	}
 
-	fmt.Printf("Kelpie mock generation starting - preparing to add some magic to your code-base!\n\n")
+	for index, file := range files {
+		if files[index], err = file.finalize(); err != nil {
+			return err
+		}
+	}
 
-	for _, pkg := range config.Packages {
-		if err = g.generatePackageMocks(cwd, pkg); err != nil {
+	if !g.Force {
+		if err := checkOverwrites(files); err != nil {
 			return err
 		}
 	}
 
+	if err := writeMockFiles(files); err != nil {
+		return err
+	}
+
 	fmt.Printf("Mock generation complete!\n")
 
 	return nil
 }
 
+// findAnnotatedMocks searches the packages matching the package patterns for any types annotated with
+// //kelpie:mock, and returns the config needed to generate mocks for them. Packages without any
+// annotated types are skipped.
+func (g *generateCmd) findAnnotatedMocks(cwd string) ([]PackageConfig, *parser.LoadedPackages, error) {
+	fmt.Printf("Searching %s for types annotated with //kelpie:mock.\n\n", strings.Join(g.Packages, ", "))
+
+	packagePaths, err := parser.FindPackages(cwd, g.Packages...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loaded, err := parser.Load(cwd, packagePaths...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var packageConfigs []PackageConfig
+	for _, packagePath := range packagePaths {
+		// We parse the package as if the mocks were being generated inside it so that annotated
+		// unexported types are found, but this doesn't affect how the mocks are generated since the
+		// package is parsed again using the options from the annotations.
+		parsedPackage, err := loaded.Parse(packagePath, &parser.IncludingInterfaceFilter{}, parser.Annotated(), parser.InPackage())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not search package '%s' for annotated types", packagePath)
+		}
+
+		var mocks []MockConfig
+		for _, i := range parsedPackage.Mocks {
+			if i.Annotation == nil {
+				continue
+			}
+
+			mockConfig, err := newAnnotatedMockConfig(i)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "invalid annotation on '%s' in package '%s'", i.FullName, packagePath)
+			}
+
+			mocks = append(mocks, mockConfig)
+		}
+
+		if len(mocks) > 0 {
+			packageConfigs = append(packageConfigs, PackageConfig{PackageName: packagePath, Mocks: mocks})
+		}
+	}
+
+	return packageConfigs, loaded, nil
+}
+
+// annotationOptions contains the options that can be specified in a //kelpie:mock annotation.
+var annotationOptions = []string{"package", "inPackage", "testFile", "methodCollisions", "collisionSuffix"}
+
+// newAnnotatedMockConfig creates the config for a type annotated with //kelpie:mock using the options
+// specified in the annotation.
+func newAnnotatedMockConfig(i parser.MockedInterface) (MockConfig, error) {
+	// The options are applied in a fixed order so that the same error is reported each time Kelpie runs,
+	// and unknown options are reported before invalid values since they're more likely to be typos.
+	keys := maps.Keys(i.Annotation.Options)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !slices.Contains(annotationOptions, func(option string) bool { return option == key }) {
+			return MockConfig{}, fmt.Errorf("unknown option '%s'. The supported options are 'package', 'inPackage', 'testFile', 'methodCollisions' and 'collisionSuffix'", key)
+		}
+	}
+
+	mockConfig := MockConfig{InterfaceName: i.FullName}
+	for _, key := range keys {
+		value := i.Annotation.Options[key]
+
+		var err error
+		switch key {
+		case "package":
+			mockConfig.GenerationOptions.PackageName = value
+		case "inPackage":
+			mockConfig.GenerationOptions.InPackage, err = strconv.ParseBool(value)
+		case "testFile":
+			mockConfig.GenerationOptions.TestFile, err = strconv.ParseBool(value)
+		case "methodCollisions":
+			mockConfig.GenerationOptions.MethodCollisions = MethodCollisionStrategy(value)
+		case "collisionSuffix":
+			mockConfig.GenerationOptions.CollisionSuffix = value
+		}
+
+		if err != nil {
+			return MockConfig{}, errors.Wrapf(err, "invalid value '%s' for option '%s'", value, key)
+		}
+	}
+
+	mockConfig.GenerationOptions = mockConfig.GenerationOptions.forType(i.Name, i.IsTestOnly)
+
+	return mockConfig, nil
+}
+
+// forType returns the generation options needed to mock the specified type. Unexported types and types
+// declared in test files can't be referenced from a separate mock package, so the only place their mocks
+// can be generated is inside the package itself.
+func (o MockGenerationOptions) forType(name string, testOnly bool) MockGenerationOptions {
+	if !token.IsExported(name) || testOnly {
+		o.InPackage = true
+	}
+
+	if testOnly {
+		o.TestFile = true
+	}
+
+	return o
+}
+
+// isGoGenerate returns true if Kelpie has been run by go generate without specifying what to mock,
+// in which case the mock is inferred from the location of the go:generate directive. Directives that
+// were written to generate the mocks listed in a config file, for example in a doc or tools file, keep
+// using the config file if there is one, or if the directive isn't followed by a type declaration.
+func (g *generateCmd) isGoGenerate(cwd string) bool {
+	if g.ConfigFile != "" || g.Package != "" || len(g.Interfaces) > 0 || os.Getenv("GOFILE") == "" || os.Getenv("GOLINE") == "" {
+		return false
+	}
+
+	for _, filename := range defaultConfigFiles {
+		if _, err := os.Stat(filepath.Join(cwd, filename)); err == nil {
+			return false
+		}
+	}
+
+	line, err := strconv.Atoi(os.Getenv("GOLINE"))
+	if err != nil {
+		return false
+	}
+
+	_, err = parser.FindTypeAfterLine(filepath.Join(cwd, os.Getenv("GOFILE")), line)
+
+	return err == nil
+}
+
+// findGoGenerateMock uses the environment variables set by go generate to find the type declared
+// directly below the go:generate directive. go generate runs in the directory containing the file,
+// so the package is found relative to the current directory.
+func (g *generateCmd) findGoGenerateMock(cwd string) ([]PackageConfig, error) {
+	filename, goPackage := os.Getenv("GOFILE"), os.Getenv("GOPACKAGE")
+	line, err := strconv.Atoi(os.Getenv("GOLINE"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse GOLINE '%s'", os.Getenv("GOLINE"))
+	}
+
+	typeName, err := parser.FindTypeAfterLine(filename, line)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find the type to mock below the go:generate directive")
+	}
+
+	packagePaths, err := parser.FindPackages(cwd, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := PackageConfig{
+		PackageName:     packagePaths[0],
+		OutputDirectory: g.OutputDir,
+		Mocks:           []MockConfig{{InterfaceName: typeName}},
+	}
+
+	if strings.HasSuffix(goPackage, "_test") {
+		pkg.Variant = parser.PackageVariantExternalTest
+	}
+
+	pkg.Mocks[0].GenerationOptions = pkg.Mocks[0].GenerationOptions.forType(typeName, strings.HasSuffix(filename, "_test.go"))
+
+	return []PackageConfig{pkg}, nil
+}
+
 var defaultConfigFiles = []string{"kelpie.yaml", "kelpie.yml"}
 
 func (g *generateCmd) tryOpenConfigFile(customFilename string) (*os.File, error) {
@@ -112,19 +339,322 @@ func (g *generateCmd) tryOpenConfigFile(customFilename string) (*os.File, error)
 	return nil, fmt.Errorf("could not find Kelpie config file in '%s'", customFilename)
 }
 
-func (g *generateCmd) generatePackageMocks(cwd string, pkg PackageConfig) error {
-	filter := parser.IncludingInterfaceFilter{
-		InterfacesToInclude: slices.Map(pkg.Mocks, func(m MockConfig) string { return m.InterfaceName }),
+// packageNames returns the names of all the packages that need to be parsed to generate the mocks,
+// including any packages containing interfaces that are combined into other mocks.
+func packageNames(packageConfigs []PackageConfig) []string {
+	var names []string
+	addName := func(name string) {
+		if !slices.Contains(names, func(n string) bool { return n == name }) {
+			names = append(names, name)
+		}
+	}
+
+	for _, pkg := range packageConfigs {
+		addName(pkg.PackageName)
+
+		for _, mockConfig := range pkg.Mocks {
+			for _, reference := range mockConfig.Combine {
+				if reference.PackageName != "" {
+					addName(reference.PackageName)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// mockFile contains a mock that's ready to be written to disk.
+type mockFile struct {
+	data mockTemplateData
+
+	// name is used as the name of the mock's package and file. For mocks generated inside the
+	// package being mocked it's only used for the file name.
+	name string
+
+	// config is the configuration of the mock.
+	config MockConfig
+
+	// sourcePackage is the path of the package being mocked.
+	sourcePackage string
+
+	// outputDirectory is the directory that the mock's package is created in, or the directory of
+	// the package being mocked if the mock is generated inside it.
+	outputDirectory string
+}
+
+// path returns the path of the file that the mock is written to.
+func (f mockFile) path() string {
+	if !f.config.GenerationOptions.InPackage {
+		return filepath.Clean(filepath.Join(f.outputDirectory, f.name, f.name+".go"))
+	}
+
+	// Interfaces declared in test files can only be referenced from other test files.
+	if f.config.GenerationOptions.TestFile || f.data.IsTestOnly {
+		return filepath.Clean(filepath.Join(f.outputDirectory, f.name+"_mock_test.go"))
+	}
+
+	return filepath.Clean(filepath.Join(f.outputDirectory, f.name+"_mock.go"))
+}
+
+// finalize chooses the names of the identifiers used by the mock. This needs to happen once the
+// mock's name is known, since the names depend on the prefix used for mocks generated inside the
+// package being mocked.
+func (f mockFile) finalize() (mockFile, error) {
+	data, err := f.data.withSetupFunctionNames(f.config.GenerationOptions)
+	if err != nil {
+		return f, err
 	}
 
+	f.data = data.withHygienicIdentifiers()
+
+	return f, nil
+}
+
+func (g *generateCmd) generatePackageMocks(loaded *parser.LoadedPackages, pkg PackageConfig) ([]mockFile, error) {
 	fmt.Printf("Parsing package '%s' for interfaces to mock.\n", pkg.PackageName)
 
-	parsedPackage, err := parser.Parse(pkg.PackageName, cwd, &filter)
+	// Nothing can import an external test package, so the only place its mocks can be
+	// generated is in test files inside the package itself.
+	if pkg.Variant == parser.PackageVariantExternalTest {
+		pkg.Mocks = slices.Map(pkg.Mocks, func(m MockConfig) MockConfig {
+			m.GenerationOptions.InPackage = true
+			m.GenerationOptions.TestFile = true
+			return m
+		})
+	}
+
+	parsedPackage, mocks, err := g.parsePackage(loaded, pkg, slices.All(pkg.Mocks, func(m MockConfig) bool { return !m.GenerationOptions.InPackage }))
 	if err != nil {
-		return errors.Wrap(err, "could not parse file")
+		return nil, err
+	}
+
+	// Interfaces declared in test files can only be used by the package's own tests, which can't
+	// import a mock package that imports the package being tested without creating an import cycle.
+	// Mocks for these interfaces that reference the package are generated inside it instead.
+	var testOnlyMocks []MockConfig
+	mocks = slices.All(mocks, func(m configuredMock) bool {
+		if !m.IsTestOnly || !importsPackage(m.Imports, parsedPackage.PackagePath) {
+			return true
+		}
+
+		// The config could be a pattern matching other interfaces, so we only move this interface.
+		mockConfig := MockConfig{InterfaceName: m.FullName, Combine: m.config.Combine, GenerationOptions: m.config.GenerationOptions}
+		mockConfig.GenerationOptions.InPackage = true
+		mockConfig.GenerationOptions.TestFile = true
+		testOnlyMocks = append(testOnlyMocks, mockConfig)
+
+		return false
+	})
+
+	// Mocks generated inside the package being mocked need to be parsed separately, since types
+	// from the package itself don't need to be qualified.
+	inPackageMocks := append(slices.All(pkg.Mocks, func(m MockConfig) bool { return m.GenerationOptions.InPackage }), testOnlyMocks...)
+	if len(inPackageMocks) > 0 {
+		inPackage, inPackageParsedMocks, err := g.parsePackage(loaded, pkg, inPackageMocks, parser.InPackage())
+		if err != nil {
+			return nil, err
+		}
+
+		if inPackage.PackageDirectory == "" {
+			return nil, fmt.Errorf("could not find the source directory of package '%s' to generate mocks in", pkg.PackageName)
+		}
+
+		mocks = append(mocks, inPackageParsedMocks...)
+	}
+
+	baseOutputDirectory := pkg.OutputDirectory
+	if baseOutputDirectory == "" {
+		baseOutputDirectory = filepath.Join(parsedPackage.PackageDirectory, "mocks")
 	}
 
-	template := template.Must(template.New("mock").
+	var files []mockFile
+	for _, m := range mocks {
+		i, mockConfig := m.MockedInterface, m.config
+		fmt.Printf("  - Generating a mock for '%s'.\n", i.Name)
+
+		if mockConfig.hasPackageName() {
+			i.PackageName = mockConfig.GenerationOptions.PackageName
+		}
+
+		file := mockFile{
+			data:            mockTemplateData{MockedInterface: i},
+			name:            i.PackageName,
+			config:          mockConfig,
+			sourcePackage:   pkg.PackageName,
+			outputDirectory: baseOutputDirectory,
+		}
+
+		if mockConfig.GenerationOptions.InPackage {
+			// The mock is written alongside the code being mocked, so we need to prefix the mock's
+			// types and functions to avoid clashes with the package's own code and any other mocks.
+			file.data.Prefix = unexport(i.Name)
+			file.data.PackageName = parsedPackage.PackageName
+			file.outputDirectory = parsedPackage.PackageDirectory
+		}
+
+		files = append(files, file)
+	}
+
+	fmt.Println()
+
+	return files, nil
+}
+
+// importsPackage returns true if the imports include the package with the specified path.
+func importsPackage(imports []string, packagePath string) bool {
+	return slices.Contains(imports, func(i string) bool { return strings.HasSuffix(i, `"`+packagePath+`"`) })
+}
+
+// writeMockFiles generates the mocks and writes them to disk. The mocks are independent of each other,
+// so they're generated concurrently.
+func writeMockFiles(files []mockFile) error {
+	template := newMockTemplate()
+	errs := make([]error, len(files))
+
+	// Limit the number of files being generated at once to avoid running out of file handles.
+	semaphore := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for index, file := range files {
+		wg.Add(1)
+		go func(index int, file mockFile) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			errs[index] = writeMockFile(template, file)
+		}(index, file)
+	}
+
+	wg.Wait()
+
+	// Return the first error so that the error reported is the same each time Kelpie runs.
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMockFile writes the mock to a temporary file, and then renames it over the output file. This
+// means that the output file is never left partially written if generation fails or is interrupted.
+func writeMockFile(template *template.Template, file mockFile) (err error) {
+	outputDirectoryName := filepath.Dir(file.path())
+	if _, err := os.Stat(outputDirectoryName); os.IsNotExist(err) {
+		if err := os.MkdirAll(outputDirectoryName, 0700); err != nil {
+			return errors.Wrap(err, "could not create directory for mock")
+		}
+	}
+
+	// The temporary file is created in the same directory as the output file, since renames are only
+	// atomic within the same file system. The leading dot stops Go from treating it as a source file.
+	output, err := os.CreateTemp(outputDirectoryName, "."+filepath.Base(file.path())+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary output file")
+	}
+	defer func() {
+		output.Close()
+		if err != nil {
+			os.Remove(output.Name())
+		}
+	}()
+
+	var source bytes.Buffer
+	if err := template.Execute(&source, file.data); err != nil {
+		return errors.Wrapf(err, "could not generate mock for '%s'", file.data.Name)
+	}
+
+	// Types like inline interfaces aren't formatted the same way as gofmt formats them, so we
+	// format the mock to make sure it matches the rest of the code-base.
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return errors.Wrapf(err, "could not format mock for '%s'", file.data.Name)
+	}
+
+	if _, err := output.Write(formatted); err != nil {
+		return errors.Wrap(err, "could not write output file")
+	}
+
+	// CreateTemp only allows the file to be read by its owner, so we use the same permissions as
+	// any existing mock, or the standard permissions for a source file.
+	mode := fs.FileMode(0644)
+	if info, err := os.Stat(file.path()); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	if err := output.Chmod(mode); err != nil {
+		return errors.Wrap(err, "could not set permissions of output file")
+	}
+
+	if err := output.Close(); err != nil {
+		return errors.Wrap(err, "could not write output file")
+	}
+
+	if err := os.Rename(output.Name(), file.path()); err != nil {
+		return errors.Wrap(err, "could not replace output file")
+	}
+
+	return nil
+}
+
+// generatedHeader is the comment that marks a file as having been generated by Kelpie.
+const generatedHeader = "// Code generated by Kelpie. DO NOT EDIT."
+
+// checkOverwrites makes sure that Kelpie only overwrites files that it generated, so that hand-written
+// code isn't lost if a mock is accidentally configured to use the same path. All the files are checked
+// before any mocks are written so that a run either writes all the mocks or none of them.
+func checkOverwrites(files []mockFile) error {
+	var refused []string
+	for _, file := range files {
+		generated, err := isGeneratedFile(file.path())
+		if err != nil {
+			return err
+		}
+
+		if !generated {
+			refused = append(refused, file.path())
+		}
+	}
+
+	if len(refused) > 0 {
+		return fmt.Errorf("refusing to overwrite the following files because they weren't generated by Kelpie: %s. Use --force to overwrite them anyway", strings.Join(refused, ", "))
+	}
+
+	return nil
+}
+
+// isGeneratedFile returns true if the file doesn't exist, or contains Kelpie's generated code header.
+// Following the Go convention for generated files, the header has to be a line of its own that appears
+// before the first non-comment, non-blank text in the file.
+func isGeneratedFile(path string) (bool, error) {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrapf(err, "could not read existing file '%s'", path)
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == generatedHeader {
+			return true, nil
+		}
+
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "//") {
+			break
+		}
+	}
+
+	return false, nil
+}
+
+func newMockTemplate() *template.Template {
+	return template.Must(template.New("mock").
 		Funcs(template.FuncMap{
 			"CommentBlock": func(comment string) string {
 				lines := strings.Split(comment, "\n")
@@ -136,63 +666,260 @@ func (g *generateCmd) generatePackageMocks(cwd string, pkg PackageConfig) error
 					return "// " + line
 				}), "\n")
 			},
-			"Unexport": func(name string) string {
-				firstRune, size := utf8.DecodeRuneInString(name)
-				if firstRune == utf8.RuneError && size <= 1 {
-					return name
-				}
-
-				lower := unicode.ToLower(firstRune)
-				if firstRune == lower {
-					return name
-				}
-
-				return string(lower) + name[size:]
+			"Indent": func(s string) string {
+				return strings.ReplaceAll(s, "\n", "\n\t")
 			},
+			"Unexport": unexport,
 		}).
 		Parse(mockTemplate))
+}
 
-	baseOutputDirectory := pkg.OutputDirectory
-	if baseOutputDirectory == "" {
-		baseOutputDirectory = filepath.Join(parsedPackage.PackageDirectory, "mocks")
+// configuredMock is a mock parsed from a package, along with the configuration that selected it.
+type configuredMock struct {
+	parser.MockedInterface
+	config MockConfig
+}
+
+func (g *generateCmd) parsePackage(loaded *parser.LoadedPackages, pkg PackageConfig, mocks []MockConfig, options ...parser.ParseOption) (*parser.ParsedPackage, []configuredMock, error) {
+	mocksToParse := slices.All(mocks, func(m MockConfig) bool { return len(m.Combine) == 0 })
+	filters := make([]parser.InterfaceFilter, len(mocksToParse))
+	for index, mockConfig := range mocksToParse {
+		filter, err := mockConfig.Filter()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid mock configuration for package '%s'", pkg.PackageName)
+		}
+
+		filters[index] = filter
+	}
+
+	if pkg.Variant != "" {
+		options = append(options, parser.Variant(pkg.Variant))
+	}
+
+	parsedPackage, err := loaded.Parse(pkg.PackageName, &parser.AnyInterfaceFilter{Filters: filters}, options...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not parse file")
 	}
 
+	var parsedMocks []configuredMock
 	for _, i := range parsedPackage.Mocks {
-		fmt.Printf("  - Generating a mock for '%s'.\n", i.Name)
+		mockConfig, ok := findMockConfig(mocksToParse, filters, i.FullName)
+		if !ok {
+			return nil, nil, fmt.Errorf("could not find the configuration for the mock of '%s'", i.FullName)
+		}
 
-		mockConfig := slices.FirstOrPanic(pkg.Mocks, func(m MockConfig) bool { return m.InterfaceName == i.FullName })
-		if mockConfig.GenerationOptions.PackageName != "" {
-			i.PackageName = mockConfig.GenerationOptions.PackageName
+		parsedMocks = append(parsedMocks, configuredMock{MockedInterface: i, config: mockConfig})
+	}
+
+	for _, mockConfig := range slices.All(mocks, func(m MockConfig) bool { return len(m.Combine) > 0 }) {
+		if mockConfig.IsPattern() {
+			return nil, nil, fmt.Errorf("combined mocks must be named using 'interface' rather than a pattern, but got pattern '%s%s'", mockConfig.Pattern, mockConfig.Regex)
 		}
 
-		err := func() error {
-			outputDirectoryName := filepath.Join(baseOutputDirectory, i.PackageName)
-			if _, err := os.Stat(outputDirectoryName); os.IsNotExist(err) {
-				if err := os.MkdirAll(outputDirectoryName, 0700); err != nil {
-					return errors.Wrap(err, "could not create directory for mock")
-				}
-			}
-			file, err := os.Create(filepath.Clean(filepath.Join(outputDirectoryName, fmt.Sprintf("%s.go", i.PackageName))))
-			if err != nil {
-				return errors.Wrap(err, "could not open output file")
-			}
-			defer file.Close()
+		combined, err := g.combineInterfaces(loaded, pkg, mockConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parsedMocks = append(parsedMocks, configuredMock{MockedInterface: combined, config: mockConfig})
+	}
+
+	return parsedPackage, parsedMocks, nil
+}
+
+// findMockConfig finds the configuration that selected the specified interface. Mocks configured by
+// name take precedence over patterns, allowing the generation options of individual interfaces that
+// also match a pattern to be customized.
+func findMockConfig(mocks []MockConfig, filters []parser.InterfaceFilter, name string) (MockConfig, bool) {
+	var patternMatch *MockConfig
+	for index, mockConfig := range mocks {
+		if !filters[index].Include(name) {
+			continue
+		}
+
+		if !mockConfig.IsPattern() {
+			return mockConfig, true
+		}
+
+		if patternMatch == nil {
+			patternMatch = &mocks[index]
+		}
+	}
+
+	if patternMatch == nil {
+		return MockConfig{}, false
+	}
+
+	return *patternMatch, true
+}
+
+func (g *generateCmd) combineInterfaces(loaded *parser.LoadedPackages, pkg PackageConfig, mockConfig MockConfig) (parser.MockedInterface, error) {
+	// The interfaces share their imports so that packages with the same name, referenced by
+	// different interfaces, are given different aliases in the combined mock.
+	imports := parser.NewImportSet()
+
+	var interfaces []parser.MockedInterface
+	for _, reference := range mockConfig.Combine {
+		if reference.PackageName == "" {
+			reference.PackageName = pkg.PackageName
+		}
+
+		fmt.Printf("Parsing package '%s' for interface '%s' to combine into '%s'.\n", reference.PackageName, reference.InterfaceName, mockConfig.InterfaceName)
 
-			if err := template.Execute(file, i); err != nil {
-				return errors.Wrap(err, "could not generate mock")
+		// Types from the package containing the mock don't need to be qualified if the mock is
+		// being generated in that package.
+		options := []parser.ParseOption{parser.SharedImports(imports)}
+		if reference.PackageName == pkg.PackageName {
+			if mockConfig.GenerationOptions.InPackage {
+				options = append(options, parser.InPackage())
 			}
 
-			return nil
-		}()
+			if pkg.Variant != "" {
+				options = append(options, parser.Variant(pkg.Variant))
+			}
+		}
 
+		filter := parser.IncludingInterfaceFilter{InterfacesToInclude: []string{reference.InterfaceName}}
+		parsedPackage, err := loaded.Parse(reference.PackageName, &filter, options...)
 		if err != nil {
-			return err
+			return parser.MockedInterface{}, errors.Wrap(err, "could not parse file")
 		}
+
+		i, ok := slices.First(parsedPackage.Mocks, func(m parser.MockedInterface) bool { return m.FullName == reference.InterfaceName })
+		if !ok {
+			return parser.MockedInterface{}, fmt.Errorf("could not find interface '%s' in package '%s' to combine into '%s'", reference.InterfaceName, reference.PackageName, mockConfig.InterfaceName)
+		}
+
+		interfaces = append(interfaces, i)
 	}
 
-	fmt.Println()
+	combined, err := parser.Combine(mockConfig.InterfaceName, interfaces...)
+	if err != nil {
+		return parser.MockedInterface{}, errors.Wrapf(err, "could not combine interfaces into '%s'", mockConfig.InterfaceName)
+	}
 
-	return nil
+	return combined, nil
+}
+
+// newMockConfig creates the config for an interface specified on the command line. The name can
+// either be the name of an interface, a glob pattern, or a regular expression wrapped in slashes.
+func newMockConfig(name string, exclude []string) MockConfig {
+	if len(name) > 1 && strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/") {
+		return MockConfig{Regex: name[1 : len(name)-1], Exclude: exclude}
+	}
+
+	// Instantiations of generic interfaces can contain a "*" when pointer types are used as type
+	// arguments, for example "Cache[string, *users.User]", so they're never treated as patterns.
+	if strings.ContainsAny(name, "*?") && !strings.Contains(name, "[") {
+		return MockConfig{Pattern: name, Exclude: exclude}
+	}
+
+	return MockConfig{InterfaceName: name}
+}
+
+// mockTemplateData contains the information needed to generate a mock.
+type mockTemplateData struct {
+	parser.MockedInterface
+
+	// Prefix is added to the names of the generated types and functions. It's used when generating
+	// a mock inside the package being mocked, to avoid clashes with the package's own code.
+	Prefix string
+
+	// setupFunctionNames contains the names of any setup functions that have been renamed to avoid
+	// collisions, keyed by method name.
+	setupFunctionNames map[string]string
+}
+
+// MockTypeName returns the name of the mock type.
+func (d mockTemplateData) MockTypeName() string {
+	return d.Prefix + "Mock"
+}
+
+// ConstructorName returns the name of the function used to create the mock.
+func (d mockTemplateData) ConstructorName() string {
+	if d.Prefix == "" {
+		return "NewMock"
+	}
+
+	return "new" + export(d.Prefix) + "Mock"
+}
+
+// InstanceTypeName returns the name of the type that implements the mocked interface.
+func (d mockTemplateData) InstanceTypeName() string {
+	if d.Prefix == "" {
+		return "instance"
+	}
+
+	return d.Prefix + "Instance"
+}
+
+// InterfaceName returns the name of the interface generated when mocking a struct.
+func (d mockTemplateData) InterfaceName() string {
+	if d.Prefix == "" {
+		return d.Name
+	}
+
+	return d.Prefix + "Interface"
+}
+
+// SetupFunctionName returns the name of the function used to setup expectations for the method.
+func (d mockTemplateData) SetupFunctionName(methodName string) string {
+	if name, ok := d.setupFunctionNames[methodName]; ok {
+		return name
+	}
+
+	if d.Prefix == "" {
+		return methodName
+	}
+
+	return d.Prefix + export(methodName)
+}
+
+// matcherTypeParameter is a parameter that's matched using one of the type parameters of its method's
+// setup function, allowing either a value or a matcher to be passed for it.
+type matcherTypeParameter struct {
+	parser.ParameterDefinition
+
+	// Index is the position of the parameter in the method's parameter list.
+	Index int
+}
+
+// MatcherTypeParameters returns the parameters of the method that need a type parameter in its setup
+// function. Go doesn't allow non-empty interfaces in a union, so interface parameters are accepted as
+// any instead, which also allows an untyped nil to be passed for them. Variadic parameters are always
+// accepted as ...any.
+func (d mockTemplateData) MatcherTypeParameters(method parser.MethodDefinition) []matcherTypeParameter {
+	var parameters []matcherTypeParameter
+	for index, parameter := range method.Parameters {
+		if !parameter.IsVariadic && !parameter.IsNonEmptyInterface {
+			parameters = append(parameters, matcherTypeParameter{ParameterDefinition: parameter, Index: index})
+		}
+	}
+
+	return parameters
+}
+
+func unexport(name string) string {
+	firstRune, size := utf8.DecodeRuneInString(name)
+	if firstRune == utf8.RuneError && size <= 1 {
+		return name
+	}
+
+	lower := unicode.ToLower(firstRune)
+	if firstRune == lower {
+		return name
+	}
+
+	return string(lower) + name[size:]
+}
+
+func export(name string) string {
+	firstRune, size := utf8.DecodeRuneInString(name)
+	if firstRune == utf8.RuneError && size <= 1 {
+		return name
+	}
+
+	return string(unicode.ToUpper(firstRune)) + name[size:]
 }
 
 var cli struct {
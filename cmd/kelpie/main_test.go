@@ -0,0 +1,358 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/internal/testmodule"
+	"github.com/adamconnelly/kelpie/parser"
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+type GenerateTests struct {
+	suite.Suite
+}
+
+func (t *GenerateTests) Test_GeneratePackageMocks_GeneratesTestOnlyMocksReferencingThePackageInsideThePackage() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go": `package users`,
+		"users/users_test.go": `package users
+
+type testUser struct {
+	ID int
+}
+
+type UserCache interface {
+	Get(id int) testUser
+}`,
+	})
+
+	// Act
+	files := t.GeneratePackageMocks(moduleDir, PackageConfig{
+		PackageName: "github.com/adamconnelly/kelpie-test/users",
+		Mocks:       []MockConfig{{InterfaceName: "UserCache"}},
+	})
+
+	// Assert
+	t.Require().Len(files, 1)
+	t.Equal(filepath.Join(moduleDir, "users", "usercache_mock_test.go"), files[0].path())
+	t.Equal("users", files[0].data.PackageName)
+	t.Equal("userCache", files[0].data.Prefix)
+	t.Empty(files[0].data.Imports)
+}
+
+func (t *GenerateTests) Test_GeneratePackageMocks_OnlyMovesTestOnlyMocksReferencingThePackage() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go": `package users`,
+		"users/users_test.go": `package users
+
+import "io"
+
+type testUser struct {
+	ID int
+}
+
+type UserCache interface {
+	Get(id int) testUser
+}
+
+type Loader interface {
+	Load(r io.Reader) error
+}`,
+	})
+
+	// Act
+	files := t.GeneratePackageMocks(moduleDir, PackageConfig{
+		PackageName: "github.com/adamconnelly/kelpie-test/users",
+		Mocks:       []MockConfig{{Pattern: "*"}},
+	})
+
+	// Assert
+	t.Require().Len(files, 2)
+
+	loader := slices.FirstOrPanic(files, func(f mockFile) bool { return f.data.Name == "Loader" })
+	t.Equal(filepath.Join(moduleDir, "users", "mocks", "loader", "loader.go"), loader.path())
+
+	userCache := slices.FirstOrPanic(files, func(f mockFile) bool { return f.data.Name == "UserCache" })
+	t.Equal(filepath.Join(moduleDir, "users", "usercache_mock_test.go"), userCache.path())
+}
+
+func (t *GenerateTests) Test_NewAnnotatedMockConfig_AppliesOptions() {
+	// Arrange
+	i := newAnnotatedInterface("Store", map[string]string{"package": "storemock", "testFile": "true", "collisionSuffix": "Fn"})
+
+	// Act
+	mockConfig, err := newAnnotatedMockConfig(i)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("Store", mockConfig.InterfaceName)
+	t.Equal(MockGenerationOptions{PackageName: "storemock", TestFile: true, CollisionSuffix: "Fn"}, mockConfig.GenerationOptions)
+}
+
+func (t *GenerateTests) Test_NewAnnotatedMockConfig_ReportsFirstUnknownOptionInOrder() {
+	// Arrange
+	i := newAnnotatedInterface("Store", map[string]string{"zebra": "1", "inPackage": "maybe", "aardvark": "2", "mammoth": "3"})
+
+	// Act
+	for range 10 {
+		_, err := newAnnotatedMockConfig(i)
+
+		// Assert
+		t.EqualError(err, "unknown option 'aardvark'. The supported options are 'package', 'inPackage', 'testFile', 'methodCollisions' and 'collisionSuffix'")
+	}
+}
+
+func (t *GenerateTests) Test_NewAnnotatedMockConfig_ReportsFirstInvalidValueInOrder() {
+	// Arrange
+	i := newAnnotatedInterface("Store", map[string]string{"testFile": "sometimes", "inPackage": "maybe"})
+
+	// Act
+	for range 10 {
+		_, err := newAnnotatedMockConfig(i)
+
+		// Assert
+		t.ErrorContains(err, "invalid value 'maybe' for option 'inPackage'")
+	}
+}
+
+func (t *GenerateTests) Test_IsGoGenerate_ReturnsTrueForDirectiveAboveType() {
+	// Arrange
+	dir := t.WriteGoGenerateFile(`package notifications
+
+//go:generate kelpie generate
+type Notifier interface {
+	Notify(message string) error
+}`, 3)
+
+	// Act
+	isGoGenerate := new(generateCmd).isGoGenerate(dir)
+
+	// Assert
+	t.True(isGoGenerate)
+}
+
+func (t *GenerateTests) Test_IsGoGenerate_ReturnsFalseWhenConfigFileExists() {
+	// Arrange
+	dir := t.WriteGoGenerateFile(`package notifications
+
+//go:generate kelpie generate
+type Notifier interface {
+	Notify(message string) error
+}`, 3)
+	t.Require().NoError(os.WriteFile(filepath.Join(dir, "kelpie.yaml"), []byte("version: 1"), 0600))
+
+	// Act
+	isGoGenerate := new(generateCmd).isGoGenerate(dir)
+
+	// Assert
+	t.False(isGoGenerate)
+}
+
+func (t *GenerateTests) Test_IsGoGenerate_ReturnsFalseWhenDirectiveIsNotAboveType() {
+	// Arrange
+	dir := t.WriteGoGenerateFile(`//go:generate kelpie generate
+
+// Package notifications sends notifications.
+package notifications
+
+import _ "embed"`, 1)
+
+	// Act
+	isGoGenerate := new(generateCmd).isGoGenerate(dir)
+
+	// Assert
+	t.False(isGoGenerate)
+}
+
+func (t *GenerateTests) Test_IsGoGenerate_ReturnsFalseWhenMocksAreSpecified() {
+	// Arrange
+	dir := t.WriteGoGenerateFile(`package notifications
+
+//go:generate kelpie generate --package example.com/notifications --interfaces Notifier
+type Notifier interface {
+	Notify(message string) error
+}`, 3)
+
+	// Act
+	isGoGenerate := (&generateCmd{Package: "example.com/notifications", Interfaces: []string{"Notifier"}}).isGoGenerate(dir)
+
+	// Assert
+	t.False(isGoGenerate)
+}
+
+func (t *GenerateTests) Test_Run_RefusesToOverwriteFilesNotGeneratedByKelpie() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go":       usersPackage,
+		"mocks/store/store.go": "package store\n\n// Hand-written code that shouldn't be lost.\n",
+	})
+	t.Chdir(moduleDir)
+
+	// Act
+	err := (&generateCmd{Package: "github.com/adamconnelly/kelpie-test/users", Interfaces: []string{"Store"}, OutputDir: "mocks"}).Run()
+
+	// Assert
+	t.ErrorContains(err, "refusing to overwrite the following files because they weren't generated by Kelpie: "+filepath.Join("mocks", "store", "store.go"))
+	t.Contains(t.ReadFile(filepath.Join(moduleDir, "mocks", "store", "store.go")), "Hand-written code")
+}
+
+func (t *GenerateTests) Test_Run_OverwritesFilesNotGeneratedByKelpieWhenForced() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go":       usersPackage,
+		"mocks/store/store.go": "package store\n\n// Hand-written code that shouldn't be lost.\n",
+	})
+	t.Chdir(moduleDir)
+
+	// Act
+	err := (&generateCmd{Package: "github.com/adamconnelly/kelpie-test/users", Interfaces: []string{"Store"}, OutputDir: "mocks", Force: true}).Run()
+
+	// Assert
+	t.NoError(err)
+	t.Contains(t.ReadFile(filepath.Join(moduleDir, "mocks", "store", "store.go")), generatedHeader)
+}
+
+func (t *GenerateTests) Test_Run_WritesMocksWhenTheFileDoesNotExist() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go": usersPackage,
+	})
+	t.Chdir(moduleDir)
+
+	// Act
+	err := (&generateCmd{Package: "github.com/adamconnelly/kelpie-test/users", Interfaces: []string{"Store"}, OutputDir: "mocks"}).Run()
+
+	// Assert
+	t.NoError(err)
+	t.Contains(t.ReadFile(filepath.Join(moduleDir, "mocks", "store", "store.go")), generatedHeader)
+}
+
+func (t *GenerateTests) Test_IsGeneratedFile_OnlyAcceptsHeaderBeforePackageClause() {
+	tests := []struct {
+		name        string
+		contents    string
+		isGenerated bool
+	}{
+		{name: "header first", contents: generatedHeader + "\n\npackage store\n", isGenerated: true},
+		{name: "header after other comments", contents: "//go:build tools\n\n" + generatedHeader + "\r\n\npackage store\n", isGenerated: true},
+		{name: "header after package clause", contents: "package store\n\n" + generatedHeader + "\n", isGenerated: false},
+		{name: "header in block comment", contents: "/*\n" + generatedHeader + "\n*/\npackage store\n", isGenerated: false},
+		{name: "indented header", contents: "\t" + generatedHeader + "\npackage store\n", isGenerated: false},
+		{name: "header from another generator", contents: "// Code generated by mockery. DO NOT EDIT.\n\npackage store\n", isGenerated: false},
+		{name: "no header", contents: "package store\n", isGenerated: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func() {
+			// Arrange
+			path := filepath.Join(t.T().TempDir(), "store.go")
+			t.Require().NoError(os.WriteFile(path, []byte(test.contents), 0600))
+
+			// Act
+			isGenerated, err := isGeneratedFile(path)
+
+			// Assert
+			t.NoError(err)
+			t.Equal(test.isGenerated, isGenerated)
+		})
+	}
+}
+
+func (t *GenerateTests) Test_IsGeneratedFile_ReturnsTrueWhenFileDoesNotExist() {
+	// Act
+	isGenerated, err := isGeneratedFile(filepath.Join(t.T().TempDir(), "store.go"))
+
+	// Assert
+	t.NoError(err)
+	t.True(isGenerated)
+}
+
+func (t *GenerateTests) Test_WriteMockFile_LeavesExistingFileWhenGenerationFails() {
+	// Arrange
+	dir := t.T().TempDir()
+	file := mockFile{
+		data:            mockTemplateData{MockedInterface: parser.MockedInterface{Name: "Store", PackageName: "store"}},
+		name:            "store",
+		outputDirectory: dir,
+	}
+	t.Require().NoError(os.MkdirAll(filepath.Dir(file.path()), os.ModePerm))
+	t.Require().NoError(os.WriteFile(file.path(), []byte(generatedHeader+"\n\npackage store\n"), 0600))
+
+	failing := template.Must(template.New("mock").Parse(`package {{ .PackageName }}{{ .Missing }}`))
+
+	// Act
+	err := writeMockFile(failing, file)
+
+	// Assert
+	t.ErrorContains(err, "could not generate mock for 'Store'")
+
+	contents, err := os.ReadFile(file.path())
+	t.Require().NoError(err)
+	t.Equal(generatedHeader+"\n\npackage store\n", string(contents))
+
+	entries, err := os.ReadDir(filepath.Dir(file.path()))
+	t.Require().NoError(err)
+	t.Len(entries, 1)
+}
+
+// Chdir changes the working directory for the duration of the test.
+func (t *GenerateTests) Chdir(dir string) {
+	cwd, err := os.Getwd()
+	t.Require().NoError(err)
+	t.Require().NoError(os.Chdir(dir))
+
+	t.T().Cleanup(func() { t.Require().NoError(os.Chdir(cwd)) })
+}
+
+// ReadFile returns the contents of the file.
+func (t *GenerateTests) ReadFile(path string) string {
+	contents, err := os.ReadFile(path)
+	t.Require().NoError(err)
+
+	return string(contents)
+}
+
+// WriteGoGenerateFile writes a Go file, and sets the environment variables used by go generate to
+// run a directive on the specified line of the file.
+func (t *GenerateTests) WriteGoGenerateFile(input string, line int) string {
+	dir := t.T().TempDir()
+	t.Require().NoError(os.WriteFile(filepath.Join(dir, "notifications.go"), []byte(input), 0600))
+
+	t.T().Setenv("GOFILE", "notifications.go")
+	t.T().Setenv("GOLINE", strconv.Itoa(line))
+
+	return dir
+}
+
+func (t *GenerateTests) GeneratePackageMocks(moduleDir string, pkg PackageConfig) []mockFile {
+	loaded, err := parser.Load(moduleDir, pkg.PackageName)
+	t.Require().NoError(err)
+
+	files, err := new(generateCmd).generatePackageMocks(loaded, pkg)
+	t.Require().NoError(err)
+
+	return files
+}
+
+func newAnnotatedInterface(name string, options map[string]string) parser.MockedInterface {
+	return parser.MockedInterface{Name: name, FullName: name, Annotation: &parser.MockAnnotation{Options: options}}
+}
+
+// usersPackage is a package containing an interface to mock.
+const usersPackage = `package users
+
+type Store interface {
+	Save(name string) error
+}`
+
+func TestGenerate(t *testing.T) {
+	suite.Run(t, new(GenerateTests))
+}
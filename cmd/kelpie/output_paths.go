@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/adamconnelly/kelpie/parser"
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+// disambiguation is a naming scheme used to give mocks that would be written to the same file
+// different names.
+type disambiguation func(file mockFile) mockFile
+
+// disambiguations contains the naming schemes tried in order until the mocks have unique paths. Mocks
+// for nested interfaces are first named using the full path to the interface, for example
+// ConfigService.Storage becomes configservicestorage. Mocks for interfaces from different packages
+// are then prefixed with the name of the package being mocked, for example usersstorage.
+var disambiguations = []disambiguation{
+	func(file mockFile) mockFile {
+		file.name = strings.ToLower(lettersAndDigits(file.data.FullName))
+		if file.config.GenerationOptions.InPackage {
+			// In-package mocks share the package's scope, so the prefix needs to be unique as well.
+			file.data.Prefix = unexport(lettersAndDigits(file.data.FullName))
+		} else {
+			file.data.PackageName = file.name
+		}
+
+		return file
+	},
+	func(file mockFile) mockFile {
+		// Mocks generated inside a package can't clash with mocks from other packages.
+		if file.config.GenerationOptions.InPackage {
+			return file
+		}
+
+		file.name = strings.ToLower(lettersAndDigits(sourcePackageName(file.sourcePackage) + file.data.FullName))
+		file.data.PackageName = file.name
+
+		return file
+	},
+}
+
+// resolveOutputPaths makes sure that none of the mocks are written to the same file, since otherwise
+// they would silently overwrite each other. When disambiguate is true any mocks that clash are renamed,
+// as long as their package names haven't been explicitly configured. An error is returned describing
+// any clashes that remain.
+func resolveOutputPaths(files []mockFile, disambiguate bool) ([]mockFile, error) {
+	if disambiguate {
+		for _, rename := range disambiguations {
+			for _, conflict := range findOutputConflicts(files) {
+				for _, index := range conflict {
+					if !files[index].config.hasPackageName() {
+						files[index] = rename(files[index])
+					}
+				}
+			}
+		}
+	}
+
+	conflicts := findOutputConflicts(files)
+	if len(conflicts) == 0 {
+		return files, nil
+	}
+
+	var report strings.Builder
+	report.WriteString("the following mocks would be written to the same files:\n")
+	for _, conflict := range conflicts {
+		fmt.Fprintf(&report, "  - %s: %s\n", files[conflict[0]].path(), strings.Join(slices.Map(conflict, func(index int) string {
+			return fmt.Sprintf("'%s' from '%s'", files[index].data.FullName, files[index].sourcePackage)
+		}), ", "))
+	}
+	report.WriteString("Use the 'package' generation option to give the mocks different names, or run with --disambiguate to rename them automatically")
+
+	return files, errors.New(report.String())
+}
+
+// findOutputConflicts returns the indexes of any mocks that would be written to the same file, grouped
+// by path and sorted so that conflicts are reported in the same order each time.
+func findOutputConflicts(files []mockFile) [][]int {
+	indexesByPath := map[string][]int{}
+	for index, file := range files {
+		indexesByPath[file.path()] = append(indexesByPath[file.path()], index)
+	}
+
+	var conflicts [][]int
+	for _, indexes := range indexesByPath {
+		if len(indexes) > 1 {
+			conflicts = append(conflicts, indexes)
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i][0] < conflicts[j][0] })
+
+	return conflicts
+}
+
+// sourcePackageName returns the last element of a package path, ignoring any major version suffix.
+// For example github.com/org/lib/v2 returns lib.
+func sourcePackageName(packagePath string) string {
+	elements := strings.Split(packagePath, "/")
+	if len(elements) > 1 && parser.IsMajorVersion(elements[len(elements)-1]) {
+		elements = elements[:len(elements)-1]
+	}
+
+	return elements[len(elements)-1]
+}
+
+// lettersAndDigits returns just the letters and digits from the name, keeping their case, for
+// example turning "ConfigService.Storage" into "ConfigServiceStorage".
+func lettersAndDigits(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, name)
+}
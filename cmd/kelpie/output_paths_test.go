@@ -0,0 +1,152 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/parser"
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+type OutputPathsTests struct {
+	suite.Suite
+}
+
+func (t *OutputPathsTests) Test_ResolveOutputPaths_ReportsClashesWithTheirSourcePackages() {
+	// Arrange
+	files := []mockFile{
+		newMockFile("example.com/users", "Storage"),
+		newMockFile("example.com/orders", "Storage"),
+	}
+
+	// Act
+	_, err := resolveOutputPaths(files, false)
+
+	// Assert
+	t.EqualError(err, "the following mocks would be written to the same files:\n"+
+		"  - "+filepath.Join("mocks", "storage", "storage.go")+": 'Storage' from 'example.com/users', 'Storage' from 'example.com/orders'\n"+
+		"Use the 'package' generation option to give the mocks different names, or run with --disambiguate to rename them automatically")
+}
+
+func (t *OutputPathsTests) Test_ResolveOutputPaths_DoesNotReportMocksWithDifferentPaths() {
+	// Arrange
+	files := []mockFile{
+		newMockFile("example.com/users", "Storage"),
+		newMockFile("example.com/users", "Cache"),
+	}
+
+	// Act
+	result, err := resolveOutputPaths(files, false)
+
+	// Assert
+	t.NoError(err)
+	t.Equal(files, result)
+}
+
+func (t *OutputPathsTests) Test_ResolveOutputPaths_DisambiguatesUsingNestedNamesAndThenPackageNames() {
+	// Arrange
+	files := []mockFile{
+		newMockFile("example.com/users", "ConfigService.Storage"),
+		newMockFile("example.com/users", "Storage"),
+		newMockFile("example.com/orders/v2", "Storage"),
+	}
+
+	// Act
+	result, err := resolveOutputPaths(files, true)
+
+	// Assert
+	t.NoError(err)
+	t.Equal([]string{"configservicestorage", "usersstorage", "ordersstorage"}, slices.Map(result, func(f mockFile) string { return f.name }))
+	t.Equal([]string{"configservicestorage", "usersstorage", "ordersstorage"}, slices.Map(result, func(f mockFile) string { return f.data.PackageName }))
+	t.Equal(filepath.Join("mocks", "ordersstorage", "ordersstorage.go"), result[2].path())
+}
+
+func (t *OutputPathsTests) Test_ResolveOutputPaths_GivesInPackageMocksUniquePrefixes() {
+	// Arrange
+	files := []mockFile{
+		newInPackageMockFile("example.com/users", "Service.Store"),
+		newInPackageMockFile("example.com/users", "Store"),
+	}
+
+	// Act
+	result, err := resolveOutputPaths(files, true)
+
+	// Assert
+	t.NoError(err)
+	t.Equal(filepath.Join("users", "servicestore_mock.go"), result[0].path())
+	t.Equal("serviceStore", result[0].data.Prefix)
+	t.Equal("users", result[0].data.PackageName)
+	t.Equal(filepath.Join("users", "store_mock.go"), result[1].path())
+	t.Equal("store", result[1].data.Prefix)
+}
+
+func (t *OutputPathsTests) Test_ResolveOutputPaths_DoesNotRenameMocksWithConfiguredPackageNames() {
+	// Arrange
+	files := []mockFile{
+		newMockFile("example.com/users", "Storage"),
+		newMockFile("example.com/orders", "Storage"),
+	}
+
+	for index := range files {
+		files[index].config.GenerationOptions.PackageName = "storage"
+	}
+
+	// Act
+	result, err := resolveOutputPaths(files, true)
+
+	// Assert
+	t.ErrorContains(err, "'Storage' from 'example.com/users', 'Storage' from 'example.com/orders'")
+	t.Equal("storage", result[0].name)
+	t.Equal("storage", result[1].name)
+}
+
+func (t *OutputPathsTests) Test_SourcePackageName_IgnoresMajorVersionSuffix() {
+	// Act
+	name := sourcePackageName("github.com/org/lib/v2")
+
+	// Assert
+	t.Equal("lib", name)
+}
+
+func (t *OutputPathsTests) Test_SourcePackageName_ReturnsLastElementOfPath() {
+	// Act
+	name := sourcePackageName("github.com/org/lib")
+
+	// Assert
+	t.Equal("lib", name)
+}
+
+// newMockFile creates a mock for the interface that's written to the mocks directory, using the
+// default name for the mock.
+func newMockFile(sourcePackage, fullName string) mockFile {
+	names := strings.Split(fullName, ".")
+	name := names[len(names)-1]
+
+	return mockFile{
+		data: mockTemplateData{
+			MockedInterface: parser.MockedInterface{Name: name, FullName: fullName, PackageName: strings.ToLower(name)},
+		},
+		name:            strings.ToLower(name),
+		config:          MockConfig{InterfaceName: fullName},
+		sourcePackage:   sourcePackage,
+		outputDirectory: "mocks",
+	}
+}
+
+// newInPackageMockFile creates a mock for the interface that's generated inside the users package.
+func newInPackageMockFile(sourcePackage, fullName string) mockFile {
+	file := newMockFile(sourcePackage, fullName)
+	file.config.GenerationOptions.InPackage = true
+	file.data.Prefix = unexport(file.data.Name)
+	file.data.PackageName = "users"
+	file.outputDirectory = "users"
+
+	return file
+}
+
+func TestOutputPaths(t *testing.T) {
+	suite.Run(t, new(OutputPathsTests))
+}
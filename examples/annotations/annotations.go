@@ -0,0 +1,22 @@
+// Package annotations contains interfaces that are marked for mocking using //kelpie:mock annotations.
+package annotations
+
+// Notifier sends notifications to users.
+//
+//kelpie:mock
+type Notifier interface {
+	// Notify sends a message to the specified user.
+	Notify(user, message string) error
+}
+
+// Alerter raises alerts.
+//
+//kelpie:mock package=alertmock
+type Alerter interface {
+	Alert(severity int, description string)
+}
+
+// Unannotated isn't mocked, because it doesn't have a //kelpie:mock annotation.
+type Unannotated interface {
+	DoSomething()
+}
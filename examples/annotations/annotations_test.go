@@ -0,0 +1,48 @@
+package annotations_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/annotations"
+	"github.com/adamconnelly/kelpie/examples/annotations/mocks/alertmock"
+	"github.com/adamconnelly/kelpie/examples/annotations/mocks/notifier"
+)
+
+type AnnotationsTests struct {
+	suite.Suite
+}
+
+func (t *AnnotationsTests) Test_GeneratesMocksForAnnotatedInterfaces() {
+	// Arrange
+	notifierMock := notifier.NewMock()
+	notifierMock.Setup(notifier.Notify("adam", kelpie.Any[string]()).Return(errors.New("user not found")))
+
+	var n annotations.Notifier = notifierMock.Instance()
+
+	// Act
+	err := n.Notify("adam", "Hello!")
+
+	// Assert
+	t.ErrorContains(err, "user not found")
+}
+
+func (t *AnnotationsTests) Test_UsesOptionsFromAnnotation() {
+	// Arrange
+	alerterMock := alertmock.NewMock()
+
+	var a annotations.Alerter = alerterMock.Instance()
+
+	// Act
+	a.Alert(1, "Disk full")
+
+	// Assert
+	t.True(alerterMock.Called(alertmock.Alert(1, "Disk full").Once()))
+}
+
+func TestAnnotations(t *testing.T) {
+	suite.Run(t, new(AnnotationsTests))
+}
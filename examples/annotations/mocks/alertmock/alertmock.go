@@ -0,0 +1,153 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package alertmock
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Alert(severity int, description string) {
+	expectation := m.mock.Call("Alert", severity, description)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(severity int, description string))
+			observe(severity, description)
+			return
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type alertMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *alertMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Alert[P0 int | mocking.Matcher[int], P1 string | mocking.Matcher[string]](severity P0, description P1) *alertMethodMatcher {
+	result := alertMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Alert",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	if matcher, ok := any(severity).(mocking.Matcher[int]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(severity).(int))
+	}
+
+	if matcher, ok := any(description).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(description).(string))
+	}
+
+	return &result
+}
+
+type alertTimes struct {
+	matcher *alertMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *alertMethodMatcher) Times(times uint) *alertTimes {
+	m.matcher.Times = &times
+
+	return &alertTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *alertMethodMatcher) Once() *alertTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *alertMethodMatcher) Never() *alertTimes {
+	return m.Times(0)
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *alertTimes) Panic(arg any) *alertAction {
+	return &alertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *alertTimes) When(observe func(severity int, description string)) *alertAction {
+	return &alertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *alertTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *alertMethodMatcher) Panic(arg any) *alertAction {
+	return &alertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *alertMethodMatcher) When(observe func(severity int, description string)) *alertAction {
+	return &alertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type alertAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *alertAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,178 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package notifier
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+// Notify sends a message to the specified user.
+func (m *instance) Notify(user string, message string) (r0 error) {
+	expectation := m.mock.Call("Notify", user, message)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(user string, message string) error)
+			return observe(user, message)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type notifyMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *notifyMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Notify sends a message to the specified user.
+func Notify[P0 string | mocking.Matcher[string], P1 string | mocking.Matcher[string]](user P0, message P1) *notifyMethodMatcher {
+	result := notifyMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Notify",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	if matcher, ok := any(user).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(user).(string))
+	}
+
+	if matcher, ok := any(message).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(message).(string))
+	}
+
+	return &result
+}
+
+type notifyTimes struct {
+	matcher *notifyMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *notifyMethodMatcher) Times(times uint) *notifyTimes {
+	m.matcher.Times = &times
+
+	return &notifyTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *notifyMethodMatcher) Once() *notifyTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *notifyMethodMatcher) Never() *notifyTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *notifyTimes) Return(r0 error) *notifyAction {
+	return &notifyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *notifyTimes) Panic(arg any) *notifyAction {
+	return &notifyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *notifyTimes) When(observe func(user string, message string) error) *notifyAction {
+	return &notifyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *notifyTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *notifyMethodMatcher) Return(r0 error) *notifyAction {
+	return &notifyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *notifyMethodMatcher) Panic(arg any) *notifyAction {
+	return &notifyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *notifyMethodMatcher) When(observe func(user string, message string) error) *notifyAction {
+	return &notifyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type notifyAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *notifyAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,47 @@
+// Package billing contains a concrete client used to demonstrate mocking structs.
+package billing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Charge is a payment taken from a customer.
+type Charge struct {
+	ID         string
+	CustomerID string
+	Amount     int
+}
+
+// Client is a client for the billing API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewClient creates a new billing client.
+func NewClient(httpClient *http.Client, baseURL string) *Client {
+	return &Client{httpClient: httpClient, baseURL: baseURL}
+}
+
+// Charge takes a payment from the specified customer.
+//
+// The amount is specified in pence.
+func (c *Client) Charge(ctx context.Context, customerID string, amount int) (*Charge, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Refund refunds a previous charge.
+func (c *Client) Refund(ctx context.Context, chargeID string) error {
+	return errors.New("not implemented")
+}
+
+// BaseURL returns the URL of the billing API.
+func (c Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+}
@@ -0,0 +1,489 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package client
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"context"
+	"github.com/adamconnelly/kelpie/examples/billing"
+)
+
+// Client is an interface containing the exported methods of *billing.Client.
+type Client interface {
+	// BaseURL returns the URL of the billing API.
+	BaseURL() string
+
+	// Charge takes a payment from the specified customer.
+	//
+	// The amount is specified in pence.
+	Charge(ctx context.Context, customerID string, amount int) (*billing.Charge, error)
+
+	// Refund refunds a previous charge.
+	Refund(ctx context.Context, chargeID string) error
+}
+
+var _ Client = (*billing.Client)(nil)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+// BaseURL returns the URL of the billing API.
+func (m *instance) BaseURL() (r0 string) {
+	expectation := m.mock.Call("BaseURL")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() string)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(string)
+		}
+	}
+
+	return
+}
+
+// Charge takes a payment from the specified customer.
+//
+// The amount is specified in pence.
+func (m *instance) Charge(ctx context.Context, customerID string, amount int) (r0 *billing.Charge, r1 error) {
+	expectation := m.mock.Call("Charge", ctx, customerID, amount)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(ctx context.Context, customerID string, amount int) (*billing.Charge, error))
+			return observe(ctx, customerID, amount)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(*billing.Charge)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(error)
+		}
+	}
+
+	return
+}
+
+// Refund refunds a previous charge.
+func (m *instance) Refund(ctx context.Context, chargeID string) (r0 error) {
+	expectation := m.mock.Call("Refund", ctx, chargeID)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(ctx context.Context, chargeID string) error)
+			return observe(ctx, chargeID)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type baseURLMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *baseURLMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// BaseURL returns the URL of the billing API.
+func BaseURL() *baseURLMethodMatcher {
+	result := baseURLMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "BaseURL",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type baseURLTimes struct {
+	matcher *baseURLMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *baseURLMethodMatcher) Times(times uint) *baseURLTimes {
+	m.matcher.Times = &times
+
+	return &baseURLTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *baseURLMethodMatcher) Once() *baseURLTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *baseURLMethodMatcher) Never() *baseURLTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *baseURLTimes) Return(r0 string) *baseURLAction {
+	return &baseURLAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *baseURLTimes) Panic(arg any) *baseURLAction {
+	return &baseURLAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *baseURLTimes) When(observe func() string) *baseURLAction {
+	return &baseURLAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *baseURLTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *baseURLMethodMatcher) Return(r0 string) *baseURLAction {
+	return &baseURLAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *baseURLMethodMatcher) Panic(arg any) *baseURLAction {
+	return &baseURLAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *baseURLMethodMatcher) When(observe func() string) *baseURLAction {
+	return &baseURLAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type baseURLAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *baseURLAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type chargeMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *chargeMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Charge takes a payment from the specified customer.
+//
+// The amount is specified in pence.
+func Charge[P1 string | mocking.Matcher[string], P2 int | mocking.Matcher[int]](ctx any, customerID P1, amount P2) *chargeMethodMatcher {
+	result := chargeMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Charge",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 3),
+		},
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.InterfaceMatcher[context.Context](ctx)
+
+	if matcher, ok := any(customerID).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(customerID).(string))
+	}
+
+	if matcher, ok := any(amount).(mocking.Matcher[int]); ok {
+		result.matcher.ArgumentMatchers[2] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[2] = kelpie.ExactMatch(any(amount).(int))
+	}
+
+	return &result
+}
+
+type chargeTimes struct {
+	matcher *chargeMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *chargeMethodMatcher) Times(times uint) *chargeTimes {
+	m.matcher.Times = &times
+
+	return &chargeTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *chargeMethodMatcher) Once() *chargeTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *chargeMethodMatcher) Never() *chargeTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *chargeTimes) Return(r0 *billing.Charge, r1 error) *chargeAction {
+	return &chargeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *chargeTimes) Panic(arg any) *chargeAction {
+	return &chargeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *chargeTimes) When(observe func(ctx context.Context, customerID string, amount int) (*billing.Charge, error)) *chargeAction {
+	return &chargeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *chargeTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *chargeMethodMatcher) Return(r0 *billing.Charge, r1 error) *chargeAction {
+	return &chargeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *chargeMethodMatcher) Panic(arg any) *chargeAction {
+	return &chargeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *chargeMethodMatcher) When(observe func(ctx context.Context, customerID string, amount int) (*billing.Charge, error)) *chargeAction {
+	return &chargeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type chargeAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *chargeAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type refundMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *refundMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Refund refunds a previous charge.
+func Refund[P1 string | mocking.Matcher[string]](ctx any, chargeID P1) *refundMethodMatcher {
+	result := refundMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Refund",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.InterfaceMatcher[context.Context](ctx)
+
+	if matcher, ok := any(chargeID).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(chargeID).(string))
+	}
+
+	return &result
+}
+
+type refundTimes struct {
+	matcher *refundMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *refundMethodMatcher) Times(times uint) *refundTimes {
+	m.matcher.Times = &times
+
+	return &refundTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *refundMethodMatcher) Once() *refundTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *refundMethodMatcher) Never() *refundTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *refundTimes) Return(r0 error) *refundAction {
+	return &refundAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *refundTimes) Panic(arg any) *refundAction {
+	return &refundAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *refundTimes) When(observe func(ctx context.Context, chargeID string) error) *refundAction {
+	return &refundAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *refundTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *refundMethodMatcher) Return(r0 error) *refundAction {
+	return &refundAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *refundMethodMatcher) Panic(arg any) *refundAction {
+	return &refundAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *refundMethodMatcher) When(observe func(ctx context.Context, chargeID string) error) *refundAction {
+	return &refundAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type refundAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *refundAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
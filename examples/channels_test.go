@@ -0,0 +1,91 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/events"
+	"github.com/adamconnelly/kelpie/examples/events/mocks/eventbus"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type ChannelsTests struct {
+	suite.Suite
+}
+
+func (t *ChannelsTests) Test_ReturnChannel_ReturnsAChannelContainingTheValues() {
+	// Arrange
+	mock := eventbus.NewMock()
+	mock.Setup(eventbus.Subscribe("users").ReturnChannel(events.Event{Name: "UserCreated"}, events.Event{Name: "UserDeleted"}))
+
+	// Act
+	subscription, err := mock.Instance().Subscribe("users")
+
+	// Assert
+	t.NoError(err)
+
+	var received []events.Event
+	for event := range subscription {
+		received = append(received, event)
+	}
+
+	t.Equal([]events.Event{{Name: "UserCreated"}, {Name: "UserDeleted"}}, received)
+}
+
+func (t *ChannelsTests) Test_ReturnChannel_CreatesANewChannelForEachCall() {
+	// Arrange
+	mock := eventbus.NewMock()
+	mock.Setup(eventbus.Subscribe(kelpie.Any[string]()).Times(2).ReturnChannel(events.Event{Name: "UserCreated"}))
+
+	// Act
+	first, _ := mock.Instance().Subscribe("users")
+	second, _ := mock.Instance().Subscribe("users")
+
+	// Assert
+	t.Equal(events.Event{Name: "UserCreated"}, <-first)
+	t.Equal(events.Event{Name: "UserCreated"}, <-second)
+}
+
+func (t *ChannelsTests) Test_Return_CanReturnAnError() {
+	// Arrange
+	mock := eventbus.NewMock()
+	mock.Setup(eventbus.Subscribe("users").Return(nil, errors.New("topic not found")))
+
+	// Act
+	subscription, err := mock.Instance().Subscribe("users")
+
+	// Assert
+	t.Nil(subscription)
+	t.ErrorContains(err, "topic not found")
+}
+
+func (t *ChannelsTests) Test_CanMatchChannelParameters() {
+	// Arrange
+	mock := eventbus.NewMock()
+	publishedEvents := make(chan events.Event)
+
+	// Act
+	mock.Instance().Publish(publishedEvents)
+
+	// Assert
+	t.True(mock.Called(eventbus.Publish[chan<- events.Event](publishedEvents)))
+}
+
+func (t *ChannelsTests) Test_ReturnChannel_SupportsImportedTypes() {
+	// Arrange
+	mock := eventbus.NewMock()
+	mock.Setup(eventbus.Users().ReturnChannel(users.User{ID: 1}))
+
+	// Act
+	user := <-mock.Instance().Users()
+
+	// Assert
+	t.Equal(1, user.ID)
+}
+
+func TestChannels(t *testing.T) {
+	suite.Run(t, new(ChannelsTests))
+}
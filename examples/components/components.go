@@ -0,0 +1,16 @@
+// Package components contains a component whose methods collide with the API of the generated mock.
+package components
+
+// LifecycleConfig configures a component.
+type LifecycleConfig struct {
+	Name string
+}
+
+// Lifecycle has methods whose names collide with the API of the generated mock. Kelpie renames their
+// setup functions so that they can't be confused with the mock's own methods.
+type Lifecycle interface {
+	Setup(cfg LifecycleConfig) error
+	Reset()
+	Mock() string
+	Start() error
+}
@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/examples/mocks/userstore"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type CompositeMocksTests struct {
+	suite.Suite
+}
+
+func (t *CompositeMocksTests) Test_CombinedMockImplementsAllInterfaces() {
+	// Arrange
+	mock := userstore.NewMock()
+	mock.Setup(userstore.FindUserByUsername("adam").Return(&users.User{ID: 1, Username: "adam"}, nil))
+
+	var store interface {
+		users.UserRepository
+		io.Closer
+	} = mock.Instance()
+
+	// Act
+	user, err := store.FindUserByUsername("adam")
+	store.Close()
+
+	// Assert
+	t.NoError(err)
+	t.Equal(1, user.ID)
+	t.True(mock.Called(userstore.Close().Once()))
+}
+
+func TestCompositeMocks(t *testing.T) {
+	suite.Run(t, new(CompositeMocksTests))
+}
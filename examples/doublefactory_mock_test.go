@@ -0,0 +1,170 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package examples
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type doubleFactoryMock struct {
+	mocking.Mock
+	instance doubleFactoryInstance
+}
+
+func newDoubleFactoryMock() *doubleFactoryMock {
+	mock := doubleFactoryMock{
+		instance: doubleFactoryInstance{},
+	}
+	mock.instance.mock2 = &mock
+
+	return &mock
+}
+
+type doubleFactoryInstance struct {
+	mock2 *doubleFactoryMock
+}
+
+func (m *doubleFactoryInstance) mock(name string) (r0 string) {
+	expectation := m.mock2.Call("mock", name)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(name string) string)
+			return observe(name)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(string)
+		}
+	}
+
+	return
+}
+
+func (m *doubleFactoryMock) Instance() *doubleFactoryInstance {
+	return &m.instance
+}
+
+type doubleFactoryMockMethodMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *doubleFactoryMockMethodMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func doubleFactoryMockMethod[P0 string | mocking.Matcher[string]](name P0) *doubleFactoryMockMethodMethodMatcher {
+	result := doubleFactoryMockMethodMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "mock",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(name).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(name).(string))
+	}
+
+	return &result
+}
+
+type doubleFactoryMockMethodTimes struct {
+	matcher *doubleFactoryMockMethodMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *doubleFactoryMockMethodMethodMatcher) Times(times uint) *doubleFactoryMockMethodTimes {
+	m.matcher.Times = &times
+
+	return &doubleFactoryMockMethodTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *doubleFactoryMockMethodMethodMatcher) Once() *doubleFactoryMockMethodTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *doubleFactoryMockMethodMethodMatcher) Never() *doubleFactoryMockMethodTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *doubleFactoryMockMethodTimes) Return(r0 string) *doubleFactoryMockMethodAction {
+	return &doubleFactoryMockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *doubleFactoryMockMethodTimes) Panic(arg any) *doubleFactoryMockMethodAction {
+	return &doubleFactoryMockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *doubleFactoryMockMethodTimes) When(observe func(name string) string) *doubleFactoryMockMethodAction {
+	return &doubleFactoryMockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *doubleFactoryMockMethodTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *doubleFactoryMockMethodMethodMatcher) Return(r0 string) *doubleFactoryMockMethodAction {
+	return &doubleFactoryMockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *doubleFactoryMockMethodMethodMatcher) Panic(arg any) *doubleFactoryMockMethodAction {
+	return &doubleFactoryMockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *doubleFactoryMockMethodMethodMatcher) When(observe func(name string) string) *doubleFactoryMockMethodAction {
+	return &doubleFactoryMockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type doubleFactoryMockMethodAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *doubleFactoryMockMethodAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
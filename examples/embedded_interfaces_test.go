@@ -0,0 +1,47 @@
+package examples
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/examples/mocks/notificationservice"
+)
+
+type NotificationSender interface {
+	// Send sends a notification to the specified recipient.
+	Send(recipient, message string) error
+}
+
+type NotificationService interface {
+	NotificationSender
+	io.Closer
+}
+
+type EmbeddedInterfacesTests struct {
+	suite.Suite
+}
+
+func (t *EmbeddedInterfacesTests) Test_CanMockMethodsFromEmbeddedInterfaces() {
+	// Arrange
+	mock := notificationservice.NewMock()
+	mock.Setup(notificationservice.Send("someone@receiver.com", "Hello!").Return(errors.New("could not send")))
+	mock.Setup(notificationservice.Close().Return(errors.New("already closed")))
+
+	var service NotificationService = mock.Instance()
+
+	// Act
+	sendErr := service.Send("someone@receiver.com", "Hello!")
+	closeErr := service.Close()
+
+	// Assert
+	t.ErrorContains(sendErr, "could not send")
+	t.ErrorContains(closeErr, "already closed")
+	t.True(mock.Called(notificationservice.Send("someone@receiver.com", "Hello!").Once()))
+}
+
+func TestEmbeddedInterfaces(t *testing.T) {
+	suite.Run(t, new(EmbeddedInterfacesTests))
+}
@@ -0,0 +1,24 @@
+// Package events contains utilities for publishing and subscribing to events.
+package events
+
+import "github.com/adamconnelly/kelpie/examples/users"
+
+// Event is something that has happened.
+type Event struct {
+	Name string
+}
+
+// EventBus allows events to be published and subscribed to.
+type EventBus interface {
+	// Subscribe subscribes to events on the specified topic.
+	Subscribe(topic string) (<-chan Event, error)
+
+	// Publish publishes all events sent to the channel.
+	Publish(published chan<- Event)
+
+	// Users returns a channel of users that have been created.
+	Users() chan users.User
+
+	// Register registers a handler that is called for every event.
+	Register(handler interface{ Handle(event Event) error })
+}
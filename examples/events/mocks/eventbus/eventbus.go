@@ -0,0 +1,642 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package eventbus
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"github.com/adamconnelly/kelpie/examples/events"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+// Subscribe subscribes to events on the specified topic.
+func (m *instance) Subscribe(topic string) (r0 <-chan events.Event, r1 error) {
+	expectation := m.mock.Call("Subscribe", topic)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(topic string) (<-chan events.Event, error))
+			return observe(topic)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(<-chan events.Event)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(error)
+		}
+	}
+
+	return
+}
+
+// Publish publishes all events sent to the channel.
+func (m *instance) Publish(published chan<- events.Event) {
+	expectation := m.mock.Call("Publish", published)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(published chan<- events.Event))
+			observe(published)
+			return
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+	}
+
+	return
+}
+
+// Users returns a channel of users that have been created.
+func (m *instance) Users() (r0 chan users.User) {
+	expectation := m.mock.Call("Users")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() chan users.User)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(chan users.User)
+		}
+	}
+
+	return
+}
+
+// Register registers a handler that is called for every event.
+func (m *instance) Register(handler interface {
+	Handle(event events.Event) error
+}) {
+	expectation := m.mock.Call("Register", handler)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(handler interface {
+				Handle(event events.Event) error
+			}))
+			observe(handler)
+			return
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type subscribeMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *subscribeMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Subscribe subscribes to events on the specified topic.
+func Subscribe[P0 string | mocking.Matcher[string]](topic P0) *subscribeMethodMatcher {
+	result := subscribeMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Subscribe",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(topic).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(topic).(string))
+	}
+
+	return &result
+}
+
+type subscribeTimes struct {
+	matcher *subscribeMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *subscribeMethodMatcher) Times(times uint) *subscribeTimes {
+	m.matcher.Times = &times
+
+	return &subscribeTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *subscribeMethodMatcher) Once() *subscribeTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *subscribeMethodMatcher) Never() *subscribeTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *subscribeTimes) Return(r0 <-chan events.Event, r1 error) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// ReturnChannel returns a channel containing the specified values when the method is called. A new
+// channel is created for each call, and is closed once it has been filled with the values.
+func (t *subscribeTimes) ReturnChannel(values ...events.Event) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn: func(string) (r0 <-chan events.Event, r1 error) {
+				channel := make(chan events.Event, len(values))
+				for _, value := range values {
+					channel <- value
+				}
+				close(channel)
+				r0 = channel
+
+				return
+			},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *subscribeTimes) Panic(arg any) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *subscribeTimes) When(observe func(topic string) (<-chan events.Event, error)) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *subscribeTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *subscribeMethodMatcher) Return(r0 <-chan events.Event, r1 error) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// ReturnChannel returns a channel containing the specified values when the method is called. A new
+// channel is created for each call, and is closed once it has been filled with the values.
+func (m *subscribeMethodMatcher) ReturnChannel(values ...events.Event) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn: func(string) (r0 <-chan events.Event, r1 error) {
+				channel := make(chan events.Event, len(values))
+				for _, value := range values {
+					channel <- value
+				}
+				close(channel)
+				r0 = channel
+
+				return
+			},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *subscribeMethodMatcher) Panic(arg any) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *subscribeMethodMatcher) When(observe func(topic string) (<-chan events.Event, error)) *subscribeAction {
+	return &subscribeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type subscribeAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *subscribeAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type publishMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *publishMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Publish publishes all events sent to the channel.
+func Publish[P0 chan<- events.Event | mocking.Matcher[chan<- events.Event]](published P0) *publishMethodMatcher {
+	result := publishMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Publish",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(published).(mocking.Matcher[chan<- events.Event]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(published).(chan<- events.Event))
+	}
+
+	return &result
+}
+
+type publishTimes struct {
+	matcher *publishMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *publishMethodMatcher) Times(times uint) *publishTimes {
+	m.matcher.Times = &times
+
+	return &publishTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *publishMethodMatcher) Once() *publishTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *publishMethodMatcher) Never() *publishTimes {
+	return m.Times(0)
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *publishTimes) Panic(arg any) *publishAction {
+	return &publishAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *publishTimes) When(observe func(published chan<- events.Event)) *publishAction {
+	return &publishAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *publishTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *publishMethodMatcher) Panic(arg any) *publishAction {
+	return &publishAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *publishMethodMatcher) When(observe func(published chan<- events.Event)) *publishAction {
+	return &publishAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type publishAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *publishAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type usersMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *usersMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Users returns a channel of users that have been created.
+func Users() *usersMethodMatcher {
+	result := usersMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Users",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type usersTimes struct {
+	matcher *usersMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *usersMethodMatcher) Times(times uint) *usersTimes {
+	m.matcher.Times = &times
+
+	return &usersTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *usersMethodMatcher) Once() *usersTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *usersMethodMatcher) Never() *usersTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *usersTimes) Return(r0 chan users.User) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// ReturnChannel returns a channel containing the specified values when the method is called. A new
+// channel is created for each call, and is closed once it has been filled with the values.
+func (t *usersTimes) ReturnChannel(values ...users.User) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn: func() (r0 chan users.User) {
+				channel := make(chan users.User, len(values))
+				for _, value := range values {
+					channel <- value
+				}
+				close(channel)
+				r0 = channel
+
+				return
+			},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *usersTimes) Panic(arg any) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *usersTimes) When(observe func() chan users.User) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *usersTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *usersMethodMatcher) Return(r0 chan users.User) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// ReturnChannel returns a channel containing the specified values when the method is called. A new
+// channel is created for each call, and is closed once it has been filled with the values.
+func (m *usersMethodMatcher) ReturnChannel(values ...users.User) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn: func() (r0 chan users.User) {
+				channel := make(chan users.User, len(values))
+				for _, value := range values {
+					channel <- value
+				}
+				close(channel)
+				r0 = channel
+
+				return
+			},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *usersMethodMatcher) Panic(arg any) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *usersMethodMatcher) When(observe func() chan users.User) *usersAction {
+	return &usersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type usersAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *usersAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type registerMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *registerMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Register registers a handler that is called for every event.
+func Register(handler any) *registerMethodMatcher {
+	result := registerMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Register",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.InterfaceMatcher[interface {
+		Handle(event events.Event) error
+	}](handler)
+
+	return &result
+}
+
+type registerTimes struct {
+	matcher *registerMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *registerMethodMatcher) Times(times uint) *registerTimes {
+	m.matcher.Times = &times
+
+	return &registerTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *registerMethodMatcher) Once() *registerTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *registerMethodMatcher) Never() *registerTimes {
+	return m.Times(0)
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *registerTimes) Panic(arg any) *registerAction {
+	return &registerAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *registerTimes) When(observe func(handler interface {
+	Handle(event events.Event) error
+})) *registerAction {
+	return &registerAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *registerTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *registerMethodMatcher) Panic(arg any) *registerAction {
+	return &registerAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *registerMethodMatcher) When(observe func(handler interface {
+	Handle(event events.Event) error
+})) *registerAction {
+	return &registerAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type registerAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *registerAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,80 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/functions"
+	"github.com/adamconnelly/kelpie/examples/functions/mocks/authorizer"
+	"github.com/adamconnelly/kelpie/examples/functions/mocks/clock"
+	"github.com/adamconnelly/kelpie/examples/functions/mocks/validator"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type FunctionTypesTests struct {
+	suite.Suite
+}
+
+func (t *FunctionTypesTests) Test_InstanceIsAValueOfTheFunctionType() {
+	// Arrange
+	mock := clock.NewMock()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.Setup(clock.Call().Return(now))
+
+	// Act
+	var c functions.Clock = mock.Instance()
+
+	// Assert
+	t.Equal(now, c())
+}
+
+func (t *FunctionTypesTests) Test_CanMatchFunctionTypeArguments() {
+	// Arrange
+	mock := authorizer.NewMock()
+	mock.Setup(authorizer.Call(kelpie.Any[context.Context](), "Mark").Return(errors.New("Mark is not authorized")))
+
+	authorize := mock.Instance()
+
+	// Act
+	markErr := authorize(context.Background(), "Mark")
+	sarahErr := authorize(context.Background(), "Sarah")
+
+	// Assert
+	t.ErrorContains(markErr, "Mark is not authorized")
+	t.NoError(sarahErr)
+}
+
+func (t *FunctionTypesTests) Test_CanVerifyFunctionTypeCalls() {
+	// Arrange
+	mock := authorizer.NewMock()
+
+	// Act
+	mock.Instance()(context.Background(), "Sarah")
+
+	// Assert
+	t.True(mock.Called(authorizer.Call(context.Background(), "Sarah").Once()))
+	t.False(mock.Called(authorizer.Call(kelpie.Any[context.Context](), "Mark")))
+}
+
+func (t *FunctionTypesTests) Test_CanMockGenericFunctionTypes() {
+	// Arrange
+	mock := validator.NewMock[users.User]()
+	mock.Setup(validator.Call(kelpie.ExactMatch(users.User{ID: 1})).Return([]string{"name is required"}))
+
+	var validate functions.Validator[users.User] = mock.Instance()
+
+	// Act
+	errs := validate(users.User{ID: 1})
+
+	// Assert
+	t.Equal([]string{"name is required"}, errs)
+}
+
+func TestFunctionTypes(t *testing.T) {
+	suite.Run(t, new(FunctionTypesTests))
+}
@@ -0,0 +1,16 @@
+// Package functions contains function types used to demonstrate mocking functions.
+package functions
+
+import (
+	"context"
+	"time"
+)
+
+// Clock returns the current time.
+type Clock func() time.Time
+
+// Authorizer checks whether the user is allowed to perform an action.
+type Authorizer func(ctx context.Context, user string) error
+
+// Validator validates a value, returning any validation errors.
+type Validator[T any] func(value T) []string
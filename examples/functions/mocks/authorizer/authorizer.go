@@ -0,0 +1,175 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package authorizer
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"context"
+	"github.com/adamconnelly/kelpie/examples/functions"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Call(ctx context.Context, user string) (r0 error) {
+	expectation := m.mock.Call("Call", ctx, user)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(ctx context.Context, user string) error)
+			return observe(ctx, user)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() functions.Authorizer {
+	return m.instance.Call
+}
+
+type callMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *callMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Call[P1 string | mocking.Matcher[string]](ctx any, user P1) *callMethodMatcher {
+	result := callMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Call",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.InterfaceMatcher[context.Context](ctx)
+
+	if matcher, ok := any(user).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(user).(string))
+	}
+
+	return &result
+}
+
+type callTimes struct {
+	matcher *callMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *callMethodMatcher) Times(times uint) *callTimes {
+	m.matcher.Times = &times
+
+	return &callTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *callMethodMatcher) Once() *callTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *callMethodMatcher) Never() *callTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *callTimes) Return(r0 error) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *callTimes) Panic(arg any) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *callTimes) When(observe func(ctx context.Context, user string) error) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *callTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *callMethodMatcher) Return(r0 error) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *callMethodMatcher) Panic(arg any) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *callMethodMatcher) When(observe func(ctx context.Context, user string) error) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type callAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *callAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,166 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package clock
+
+import (
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"github.com/adamconnelly/kelpie/examples/functions"
+	"time"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Call() (r0 time.Time) {
+	expectation := m.mock.Call("Call")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() time.Time)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(time.Time)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() functions.Clock {
+	return m.instance.Call
+}
+
+type callMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *callMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Call() *callMethodMatcher {
+	result := callMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Call",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type callTimes struct {
+	matcher *callMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *callMethodMatcher) Times(times uint) *callTimes {
+	m.matcher.Times = &times
+
+	return &callTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *callMethodMatcher) Once() *callTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *callMethodMatcher) Never() *callTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *callTimes) Return(r0 time.Time) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *callTimes) Panic(arg any) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *callTimes) When(observe func() time.Time) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *callTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *callMethodMatcher) Return(r0 time.Time) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *callMethodMatcher) Panic(arg any) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *callMethodMatcher) When(observe func() time.Time) *callAction {
+	return &callAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type callAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *callAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
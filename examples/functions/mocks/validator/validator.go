@@ -0,0 +1,172 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package validator
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"github.com/adamconnelly/kelpie/examples/functions"
+)
+
+type Mock[T any] struct {
+	mocking.Mock
+	instance instance[T]
+}
+
+func NewMock[T any]() *Mock[T] {
+	mock := Mock[T]{
+		instance: instance[T]{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance[T any] struct {
+	mock *Mock[T]
+}
+
+func (m *instance[T]) Call(value T) (r0 []string) {
+	expectation := m.mock.Call("Call", value)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(value T) []string)
+			return observe(value)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].([]string)
+		}
+	}
+
+	return
+}
+
+func (m *Mock[T]) Instance() functions.Validator[T] {
+	return m.instance.Call
+}
+
+type callMethodMatcher[T any] struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *callMethodMatcher[T]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Call[T any, P0 mocking.Matcher[T]](value P0) *callMethodMatcher[T] {
+	result := callMethodMatcher[T]{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Call",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(value).(mocking.Matcher[T]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(value).(T))
+	}
+
+	return &result
+}
+
+type callTimes[T any] struct {
+	matcher *callMethodMatcher[T]
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *callMethodMatcher[T]) Times(times uint) *callTimes[T] {
+	m.matcher.Times = &times
+
+	return &callTimes[T]{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *callMethodMatcher[T]) Once() *callTimes[T] {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *callMethodMatcher[T]) Never() *callTimes[T] {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *callTimes[T]) Return(r0 []string) *callAction[T] {
+	return &callAction[T]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *callTimes[T]) Panic(arg any) *callAction[T] {
+	return &callAction[T]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *callTimes[T]) When(observe func(value T) []string) *callAction[T] {
+	return &callAction[T]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *callTimes[T]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *callMethodMatcher[T]) Return(r0 []string) *callAction[T] {
+	return &callAction[T]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *callMethodMatcher[T]) Panic(arg any) *callAction[T] {
+	return &callAction[T]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *callMethodMatcher[T]) When(observe func(value T) []string) *callAction[T] {
+	return &callAction[T]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type callAction[T any] struct {
+	expectation mocking.Expectation
+}
+
+func (a *callAction[T]) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,97 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/mocks/repository"
+	"github.com/adamconnelly/kelpie/examples/mocks/usercache"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type Repository[T any, ID comparable] interface {
+	// Get gets the item with the specified ID.
+	Get(id ID) (T, error)
+	FindByName(name string) ([]T, error)
+	Save(item T) error
+	Count() int
+}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+}
+
+type GenericsTests struct {
+	suite.Suite
+}
+
+func (t *GenericsTests) Test_CanMockAGenericInterface() {
+	// Arrange
+	mock := repository.NewMock[users.User, int]()
+	mock.Setup(repository.Get[users.User, int](kelpie.ExactMatch(123)).Return(users.User{ID: 123, Username: "adam"}, nil))
+	mock.Setup(repository.Get[users.User, int](kelpie.ExactMatch(456)).Return(users.User{}, errors.New("not found")))
+
+	var repo Repository[users.User, int] = mock.Instance()
+
+	// Act
+	user, err := repo.Get(123)
+	_, notFoundErr := repo.Get(456)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("adam", user.Username)
+	t.ErrorContains(notFoundErr, "not found")
+}
+
+func (t *GenericsTests) Test_CanMatchNonGenericParameters() {
+	// Arrange
+	mock := repository.NewMock[users.User, int]()
+	mock.Setup(repository.FindByName[users.User, int]("adam").Return([]users.User{{ID: 1}, {ID: 2}}, nil))
+	mock.Setup(repository.Count[users.User, int]().Return(2))
+
+	// Act
+	found, err := mock.Instance().FindByName("adam")
+	count := mock.Instance().Count()
+
+	// Assert
+	t.NoError(err)
+	t.Len(found, 2)
+	t.Equal(2, count)
+}
+
+func (t *GenericsTests) Test_CanVerifyCallsWithTypeParameters() {
+	// Arrange
+	mock := repository.NewMock[users.User, int]()
+
+	// Act
+	mock.Instance().Save(users.User{ID: 1})
+
+	// Assert
+	t.True(mock.Called(repository.Save[users.User, int](kelpie.ExactMatch(users.User{ID: 1})).Once()))
+	t.False(mock.Called(repository.Save[users.User, int](kelpie.ExactMatch(users.User{ID: 2}))))
+}
+
+func (t *GenericsTests) Test_CanMockAnInstantiationOfAGenericInterface() {
+	// Arrange
+	mock := usercache.NewMock()
+	mock.Setup(usercache.Get("adam").Return(users.User{ID: 123, Username: "adam"}, true))
+
+	var cache Cache[string, users.User] = mock.Instance()
+
+	// Act
+	user, found := cache.Get("adam")
+	cache.Set("mark", users.User{ID: 456})
+
+	// Assert
+	t.True(found)
+	t.Equal(123, user.ID)
+	t.True(mock.Called(usercache.Set("mark", kelpie.Any[users.User]())))
+}
+
+func TestGenerics(t *testing.T) {
+	suite.Run(t, new(GenericsTests))
+}
@@ -0,0 +1,71 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/mocking"
+	"github.com/adamconnelly/kelpie/examples/mocks/transformer"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+// Transformer contains parameters and results whose names clash with identifiers used by the
+// generated mock. Kelpie renames them so that the mock compiles.
+type Transformer interface {
+	Apply(m int, expectation string, result int) (mocking string, t error)
+	Lookup(users users.User, kelpie int) (r0 int, _ bool)
+	Convert(r0 string) string
+	Configure(options mocking.Options, P0 int) error
+}
+
+type IdentifiersTests struct {
+	suite.Suite
+}
+
+func (t *IdentifiersTests) Test_CanMockMethodsWithClashingParameterNames() {
+	// Arrange
+	mock := transformer.NewMock()
+	mock.Setup(transformer.Apply(1, "test", kelpie.Any[int]()).Return("applied", nil))
+
+	// Act
+	result, err := mock.Instance().Apply(1, "test", 3)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("applied", result)
+}
+
+func (t *IdentifiersTests) Test_CanMockMethodsWithParametersNamedAfterPackages() {
+	// Arrange
+	mock := transformer.NewMock()
+	mock.Setup(transformer.Lookup(users.User{ID: 1}, 2).Return(3, true))
+	mock.Setup(transformer.Configure(mocking.Options{Verbose: true}, kelpie.Any[int]()).Return(errors.New("invalid options")))
+
+	// Act
+	lookupResult, found := mock.Instance().Lookup(users.User{ID: 1}, 2)
+	err := mock.Instance().Configure(mocking.Options{Verbose: true}, 5)
+
+	// Assert
+	t.Equal(3, lookupResult)
+	t.True(found)
+	t.ErrorContains(err, "invalid options")
+}
+
+func (t *IdentifiersTests) Test_CanMockMethodsWithParametersClashingWithResultNames() {
+	// Arrange
+	mock := transformer.NewMock()
+	mock.Setup(transformer.Convert("input").When(func(r0 string) string { return r0 + " converted" }))
+
+	// Act
+	result := mock.Instance().Convert("input")
+
+	// Assert
+	t.Equal("input converted", result)
+}
+
+func TestIdentifiers(t *testing.T) {
+	suite.Run(t, new(IdentifiersTests))
+}
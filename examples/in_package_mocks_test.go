@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+)
+
+type reminder struct {
+	userID  int
+	message string
+}
+
+type reminderStore interface {
+	save(r reminder) error
+	dueReminders() []reminder
+}
+
+// doubleFactory has a method called mock, which is the same as the name of the field the generated
+// instance uses to reference its mock.
+type doubleFactory interface {
+	mock(name string) string
+}
+
+type reminderService struct {
+	store reminderStore
+}
+
+func (s *reminderService) Remind(userID int, message string) error {
+	return s.store.save(reminder{userID: userID, message: message})
+}
+
+type InPackageMocksTests struct {
+	suite.Suite
+}
+
+func (t *InPackageMocksTests) Test_CanMockUnexportedInterfaces() {
+	// Arrange
+	mock := newReminderStoreMock()
+	mock.Setup(reminderStoreSave(kelpie.Any[reminder]()).Return(errors.New("store is full")))
+
+	service := reminderService{store: mock.Instance()}
+
+	// Act
+	err := service.Remind(1, "Feed the kelpie")
+
+	// Assert
+	t.ErrorContains(err, "store is full")
+	t.True(mock.Called(reminderStoreSave(reminder{userID: 1, message: "Feed the kelpie"})))
+}
+
+func (t *InPackageMocksTests) Test_CanMockMethodsNamedMock() {
+	// Arrange
+	mock := newDoubleFactoryMock()
+	mock.Setup(doubleFactoryMockMethod("store").Return("mock store"))
+
+	// Act
+	result := mock.Instance().mock("store")
+
+	// Assert
+	t.Equal("mock store", result)
+}
+
+func (t *InPackageMocksTests) Test_CanReturnUnexportedTypes() {
+	// Arrange
+	mock := newReminderStoreMock()
+	mock.Setup(reminderStoreDueReminders().Return([]reminder{{userID: 1, message: "Feed the kelpie"}}))
+
+	// Act
+	reminders := mock.Instance().dueReminders()
+
+	// Assert
+	t.Equal([]reminder{{userID: 1, message: "Feed the kelpie"}}, reminders)
+}
+
+func TestInPackageMocks(t *testing.T) {
+	suite.Run(t, new(InPackageMocksTests))
+}
@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/events"
+	"github.com/adamconnelly/kelpie/examples/events/mocks/eventbus"
+)
+
+type loggingHandler struct {
+	name string
+}
+
+func (h *loggingHandler) Handle(event events.Event) error {
+	return nil
+}
+
+// eventHandler is the inline interface accepted by EventBus.Register.
+type eventHandler = interface {
+	Handle(event events.Event) error
+}
+
+type InlineInterfacesTests struct {
+	suite.Suite
+}
+
+func (t *InlineInterfacesTests) Test_CanMockMethodsWithInlineInterfaceParameters() {
+	// Arrange
+	mock := eventbus.NewMock()
+	handler := &loggingHandler{name: "logger"}
+	otherHandler := &loggingHandler{name: "other"}
+
+	var bus events.EventBus = mock.Instance()
+
+	// Act
+	bus.Register(handler)
+
+	// Assert
+	t.True(mock.Called(eventbus.Register(kelpie.Match(func(h eventHandler) bool { return h == handler }))))
+	t.False(mock.Called(eventbus.Register(kelpie.Match(func(h eventHandler) bool { return h == otherHandler }))))
+}
+
+func TestInlineInterfaces(t *testing.T) {
+	suite.Run(t, new(InlineInterfacesTests))
+}
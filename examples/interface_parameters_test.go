@@ -0,0 +1,118 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/mocks/archiver"
+	sm "github.com/adamconnelly/kelpie/examples/mocks/secretsmanager"
+	"github.com/adamconnelly/kelpie/examples/secretsmanager"
+)
+
+type Archiver interface {
+	Archive(ctx context.Context, destination io.Writer, sources ...io.Reader) error
+}
+
+type InterfaceParametersTests struct {
+	suite.Suite
+}
+
+func (t *InterfaceParametersTests) Test_CanPassInterfaceValuesDirectly() {
+	// Arrange
+	mock := sm.NewMock()
+	ctx := context.Background()
+	secret := "SuperSecret"
+	mock.Setup(sm.GetSecret(ctx, "MySecret").Return(&secretsmanager.GetSecretResult{Value: &secret}, nil))
+
+	// Act
+	result, err := mock.Instance().GetSecret(ctx, "MySecret")
+
+	// Assert
+	t.NoError(err)
+	t.Equal("SuperSecret", *result.Value)
+}
+
+func (t *InterfaceParametersTests) Test_InterfaceValuesMatchUsingEquality() {
+	// Arrange
+	mock := archiver.NewMock()
+	destination := &bytes.Buffer{}
+
+	// Act
+	mock.Instance().Archive(context.Background(), destination)
+
+	// Assert
+	t.True(mock.Called(archiver.Archive(context.Background(), destination)))
+	t.True(mock.Called(archiver.Archive(context.Background(), &bytes.Buffer{})))
+	t.False(mock.Called(archiver.Archive(context.Background(), bytes.NewBufferString("Hello World!"))))
+	t.False(mock.Called(archiver.Archive(context.TODO(), destination)))
+}
+
+func (t *InterfaceParametersTests) Test_CanPassVariadicInterfaceValuesDirectly() {
+	// Arrange
+	mock := archiver.NewMock()
+	destination := &bytes.Buffer{}
+	first := strings.NewReader("first")
+	second := strings.NewReader("second")
+
+	// Act
+	mock.Instance().Archive(context.Background(), destination, first, second)
+
+	// Assert
+	t.True(mock.Called(archiver.Archive(kelpie.Any[context.Context](), destination, first, second)))
+	t.True(mock.Called(archiver.Archive(kelpie.Any[context.Context](), destination, first, kelpie.Any[io.Reader]())))
+	t.False(mock.Called(archiver.Archive(kelpie.Any[context.Context](), destination, second, first)))
+}
+
+func (t *InterfaceParametersTests) Test_CanMatchNilInterfaces() {
+	// Arrange
+	mock := archiver.NewMock()
+
+	// Act
+	mock.Instance().Archive(context.Background(), nil)
+
+	// Assert
+	t.True(mock.Called(archiver.Archive(context.Background(), nil)))
+	t.True(mock.Called(archiver.Archive(context.Background(), kelpie.ExactMatch[io.Writer](nil))))
+	t.False(mock.Called(archiver.Archive(nil, nil)))
+}
+
+func (t *InterfaceParametersTests) Test_TypedNilsDoNotMatchNilInterfaces() {
+	// Arrange
+	mock := archiver.NewMock()
+	var destination *bytes.Buffer
+
+	// Act
+	mock.Instance().Archive(context.Background(), destination)
+
+	// Assert
+	t.True(mock.Called(archiver.Archive(context.Background(), destination)))
+	t.False(mock.Called(archiver.Archive(context.Background(), nil)))
+	t.False(mock.Called(archiver.Archive(context.Background(), kelpie.ExactMatch[io.Writer](nil))))
+}
+
+func (t *InterfaceParametersTests) Test_MatchersMustMatchTheParameterType() {
+	// Arrange
+	mock := archiver.NewMock()
+	destination := &bytes.Buffer{}
+	isDestination := kelpie.Match(func(w io.Writer) bool { return w == destination })
+	isBuffer := kelpie.Match(func(b *bytes.Buffer) bool { return true })
+
+	// Act
+	mock.Instance().Archive(context.Background(), destination)
+
+	// Assert
+	t.True(mock.Called(archiver.Archive(kelpie.Any[context.Context](), isDestination)))
+	t.Panics(func() { archiver.Archive(context.Background(), isBuffer) })
+	t.Panics(func() { archiver.Archive(context.Background(), kelpie.Any[string]()) })
+	t.Panics(func() { archiver.Archive(kelpie.Any[io.Writer](), destination) })
+}
+
+func TestInterfaceParameters(t *testing.T) {
+	suite.Run(t, new(InterfaceParametersTests))
+}
@@ -0,0 +1,60 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/components"
+	"github.com/adamconnelly/kelpie/examples/mocks/lifecycle"
+)
+
+type MethodCollisionTests struct {
+	suite.Suite
+}
+
+func (t *MethodCollisionTests) Test_CanSetupMethodsWithTheSameNamesAsTheMock() {
+	// Arrange
+	mock := lifecycle.NewMock()
+	mock.Setup(lifecycle.SetupMethod(kelpie.Any[components.LifecycleConfig]()).Return(errors.New("invalid config")))
+	mock.Setup(lifecycle.MockMethod().Return("lifecycle"))
+
+	// Act
+	err := mock.Instance().Setup(components.LifecycleConfig{Name: "test"})
+	name := mock.Instance().Mock()
+
+	// Assert
+	t.ErrorContains(err, "invalid config")
+	t.Equal("lifecycle", name)
+}
+
+func (t *MethodCollisionTests) Test_CanVerifyRenamedMethods() {
+	// Arrange
+	mock := lifecycle.NewMock()
+
+	// Act
+	mock.Instance().Reset()
+
+	// Assert
+	t.True(mock.Called(lifecycle.ResetMethod()))
+	t.False(mock.Called(lifecycle.Start()))
+}
+
+func (t *MethodCollisionTests) Test_MockResetStillResetsTheMock() {
+	// Arrange
+	mock := lifecycle.NewMock()
+	mock.Setup(lifecycle.Start().Return(errors.New("already started")))
+
+	// Act
+	mock.Reset()
+	err := mock.Instance().Start()
+
+	// Assert
+	t.NoError(err)
+}
+
+func TestMethodCollisions(t *testing.T) {
+	suite.Run(t, new(MethodCollisionTests))
+}
@@ -0,0 +1,8 @@
+// Package mocking has the same name as Kelpie's mocking package, and is used to test that mocks
+// referencing it alias the import to avoid a clash.
+package mocking
+
+// Options contains some options.
+type Options struct {
+	Verbose bool
+}
@@ -0,0 +1,181 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package archiver
+
+import (
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"context"
+	"io"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Archive(ctx context.Context, destination io.Writer, sources ...io.Reader) (r0 error) {
+	expectation := m.mock.Call("Archive", ctx, destination, sources)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(ctx context.Context, destination io.Writer, sources ...io.Reader) error)
+			return observe(ctx, destination, sources...)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type archiveMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *archiveMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Archive(ctx any, destination any, sources ...any) *archiveMethodMatcher {
+	result := archiveMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Archive",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 3),
+		},
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.InterfaceMatcher[context.Context](ctx)
+
+	result.matcher.ArgumentMatchers[1] = mocking.InterfaceMatcher[io.Writer](destination)
+
+	var matchers []mocking.ArgumentMatcher
+	for _, arg := range sources {
+		if matcher, ok := arg.(mocking.ArgumentMatcher); ok {
+			matchers = append(matchers, matcher)
+		} else {
+			matchers = append(matchers, mocking.InterfaceMatcher[io.Reader](arg))
+		}
+	}
+
+	result.matcher.ArgumentMatchers[2] = mocking.Variadic(matchers)
+
+	return &result
+}
+
+type archiveTimes struct {
+	matcher *archiveMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *archiveMethodMatcher) Times(times uint) *archiveTimes {
+	m.matcher.Times = &times
+
+	return &archiveTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *archiveMethodMatcher) Once() *archiveTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *archiveMethodMatcher) Never() *archiveTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *archiveTimes) Return(r0 error) *archiveAction {
+	return &archiveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *archiveTimes) Panic(arg any) *archiveAction {
+	return &archiveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *archiveTimes) When(observe func(ctx context.Context, destination io.Writer, sources ...io.Reader) error) *archiveAction {
+	return &archiveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *archiveTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *archiveMethodMatcher) Return(r0 error) *archiveAction {
+	return &archiveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *archiveMethodMatcher) Panic(arg any) *archiveAction {
+	return &archiveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *archiveMethodMatcher) When(observe func(ctx context.Context, destination io.Writer, sources ...io.Reader) error) *archiveAction {
+	return &archiveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type archiveAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *archiveAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,554 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package lifecycle
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"github.com/adamconnelly/kelpie/examples/components"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Setup(cfg components.LifecycleConfig) (r0 error) {
+	expectation := m.mock.Call("Setup", cfg)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(cfg components.LifecycleConfig) error)
+			return observe(cfg)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Reset() {
+	expectation := m.mock.Call("Reset")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func())
+			observe()
+			return
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Mock() (r0 string) {
+	expectation := m.mock.Call("Mock")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() string)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(string)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Start() (r0 error) {
+	expectation := m.mock.Call("Start")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() error)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type setupMethodMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *setupMethodMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func SetupMethod[P0 components.LifecycleConfig | mocking.Matcher[components.LifecycleConfig]](cfg P0) *setupMethodMethodMatcher {
+	result := setupMethodMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Setup",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(cfg).(mocking.Matcher[components.LifecycleConfig]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(cfg).(components.LifecycleConfig))
+	}
+
+	return &result
+}
+
+type setupMethodTimes struct {
+	matcher *setupMethodMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *setupMethodMethodMatcher) Times(times uint) *setupMethodTimes {
+	m.matcher.Times = &times
+
+	return &setupMethodTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *setupMethodMethodMatcher) Once() *setupMethodTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *setupMethodMethodMatcher) Never() *setupMethodTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *setupMethodTimes) Return(r0 error) *setupMethodAction {
+	return &setupMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *setupMethodTimes) Panic(arg any) *setupMethodAction {
+	return &setupMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *setupMethodTimes) When(observe func(cfg components.LifecycleConfig) error) *setupMethodAction {
+	return &setupMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *setupMethodTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *setupMethodMethodMatcher) Return(r0 error) *setupMethodAction {
+	return &setupMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *setupMethodMethodMatcher) Panic(arg any) *setupMethodAction {
+	return &setupMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *setupMethodMethodMatcher) When(observe func(cfg components.LifecycleConfig) error) *setupMethodAction {
+	return &setupMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type setupMethodAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *setupMethodAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type resetMethodMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *resetMethodMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func ResetMethod() *resetMethodMethodMatcher {
+	result := resetMethodMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Reset",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type resetMethodTimes struct {
+	matcher *resetMethodMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *resetMethodMethodMatcher) Times(times uint) *resetMethodTimes {
+	m.matcher.Times = &times
+
+	return &resetMethodTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *resetMethodMethodMatcher) Once() *resetMethodTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *resetMethodMethodMatcher) Never() *resetMethodTimes {
+	return m.Times(0)
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *resetMethodTimes) Panic(arg any) *resetMethodAction {
+	return &resetMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *resetMethodTimes) When(observe func()) *resetMethodAction {
+	return &resetMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *resetMethodTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *resetMethodMethodMatcher) Panic(arg any) *resetMethodAction {
+	return &resetMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *resetMethodMethodMatcher) When(observe func()) *resetMethodAction {
+	return &resetMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type resetMethodAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *resetMethodAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type mockMethodMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *mockMethodMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func MockMethod() *mockMethodMethodMatcher {
+	result := mockMethodMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Mock",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type mockMethodTimes struct {
+	matcher *mockMethodMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *mockMethodMethodMatcher) Times(times uint) *mockMethodTimes {
+	m.matcher.Times = &times
+
+	return &mockMethodTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *mockMethodMethodMatcher) Once() *mockMethodTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *mockMethodMethodMatcher) Never() *mockMethodTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *mockMethodTimes) Return(r0 string) *mockMethodAction {
+	return &mockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *mockMethodTimes) Panic(arg any) *mockMethodAction {
+	return &mockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *mockMethodTimes) When(observe func() string) *mockMethodAction {
+	return &mockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *mockMethodTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *mockMethodMethodMatcher) Return(r0 string) *mockMethodAction {
+	return &mockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *mockMethodMethodMatcher) Panic(arg any) *mockMethodAction {
+	return &mockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *mockMethodMethodMatcher) When(observe func() string) *mockMethodAction {
+	return &mockMethodAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type mockMethodAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *mockMethodAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type startMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *startMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Start() *startMethodMatcher {
+	result := startMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Start",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type startTimes struct {
+	matcher *startMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *startMethodMatcher) Times(times uint) *startTimes {
+	m.matcher.Times = &times
+
+	return &startTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *startMethodMatcher) Once() *startTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *startMethodMatcher) Never() *startTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *startTimes) Return(r0 error) *startAction {
+	return &startAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *startTimes) Panic(arg any) *startAction {
+	return &startAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *startTimes) When(observe func() error) *startAction {
+	return &startAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *startTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *startMethodMatcher) Return(r0 error) *startAction {
+	return &startAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *startMethodMatcher) Panic(arg any) *startAction {
+	return &startAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *startMethodMatcher) When(observe func() error) *startAction {
+	return &startAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type startAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *startAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,313 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package notificationservice
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+// Send sends a notification to the specified recipient.
+func (m *instance) Send(recipient string, message string) (r0 error) {
+	expectation := m.mock.Call("Send", recipient, message)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(recipient string, message string) error)
+			return observe(recipient, message)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Close() (r0 error) {
+	expectation := m.mock.Call("Close")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() error)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type sendMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *sendMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Send sends a notification to the specified recipient.
+func Send[P0 string | mocking.Matcher[string], P1 string | mocking.Matcher[string]](recipient P0, message P1) *sendMethodMatcher {
+	result := sendMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Send",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	if matcher, ok := any(recipient).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(recipient).(string))
+	}
+
+	if matcher, ok := any(message).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(message).(string))
+	}
+
+	return &result
+}
+
+type sendTimes struct {
+	matcher *sendMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *sendMethodMatcher) Times(times uint) *sendTimes {
+	m.matcher.Times = &times
+
+	return &sendTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *sendMethodMatcher) Once() *sendTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *sendMethodMatcher) Never() *sendTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *sendTimes) Return(r0 error) *sendAction {
+	return &sendAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *sendTimes) Panic(arg any) *sendAction {
+	return &sendAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *sendTimes) When(observe func(recipient string, message string) error) *sendAction {
+	return &sendAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *sendTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *sendMethodMatcher) Return(r0 error) *sendAction {
+	return &sendAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *sendMethodMatcher) Panic(arg any) *sendAction {
+	return &sendAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *sendMethodMatcher) When(observe func(recipient string, message string) error) *sendAction {
+	return &sendAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type sendAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *sendAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type closeMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *closeMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Close() *closeMethodMatcher {
+	result := closeMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Close",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type closeTimes struct {
+	matcher *closeMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *closeMethodMatcher) Times(times uint) *closeTimes {
+	m.matcher.Times = &times
+
+	return &closeTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *closeMethodMatcher) Once() *closeTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *closeMethodMatcher) Never() *closeTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *closeTimes) Return(r0 error) *closeAction {
+	return &closeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *closeTimes) Panic(arg any) *closeAction {
+	return &closeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *closeTimes) When(observe func() error) *closeAction {
+	return &closeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *closeTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *closeMethodMatcher) Return(r0 error) *closeAction {
+	return &closeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *closeMethodMatcher) Panic(arg any) *closeAction {
+	return &closeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *closeMethodMatcher) When(observe func() error) *closeAction {
+	return &closeAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type closeAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *closeAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
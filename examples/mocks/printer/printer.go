@@ -56,7 +56,7 @@ func (m *printfMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
 	return &m.matcher
 }
 
-func Printf[P0 string | mocking.Matcher[string], P1 interface{} | mocking.Matcher[interface{}]](formatString P0, args ...P1) *printfMethodMatcher {
+func Printf[P0 string | mocking.Matcher[string]](formatString P0, args ...any) *printfMethodMatcher {
 	result := printfMethodMatcher{
 		matcher: mocking.MethodMatcher{
 			MethodName:       "Printf",
@@ -72,10 +72,10 @@ func Printf[P0 string | mocking.Matcher[string], P1 interface{} | mocking.Matche
 
 	var matchers []mocking.ArgumentMatcher
 	for _, arg := range args {
-		if matcher, ok := any(arg).(mocking.ArgumentMatcher); ok {
+		if matcher, ok := arg.(mocking.ArgumentMatcher); ok {
 			matchers = append(matchers, matcher)
 		} else {
-			matchers = append(matchers, kelpie.ExactMatch(any(arg).(interface{})))
+			matchers = append(matchers, mocking.ValueMatcher[interface{}](arg))
 		}
 	}
 
@@ -0,0 +1,597 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package repository
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type Mock[T any, ID comparable] struct {
+	mocking.Mock
+	instance instance[T, ID]
+}
+
+func NewMock[T any, ID comparable]() *Mock[T, ID] {
+	mock := Mock[T, ID]{
+		instance: instance[T, ID]{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance[T any, ID comparable] struct {
+	mock *Mock[T, ID]
+}
+
+// Get gets the item with the specified ID.
+func (m *instance[T, ID]) Get(id ID) (r0 T, r1 error) {
+	expectation := m.mock.Call("Get", id)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(id ID) (T, error))
+			return observe(id)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(T)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(error)
+		}
+	}
+
+	return
+}
+
+func (m *instance[T, ID]) FindByName(name string) (r0 []T, r1 error) {
+	expectation := m.mock.Call("FindByName", name)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(name string) ([]T, error))
+			return observe(name)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].([]T)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(error)
+		}
+	}
+
+	return
+}
+
+func (m *instance[T, ID]) Save(item T) (r0 error) {
+	expectation := m.mock.Call("Save", item)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(item T) error)
+			return observe(item)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *instance[T, ID]) Count() (r0 int) {
+	expectation := m.mock.Call("Count")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() int)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(int)
+		}
+	}
+
+	return
+}
+
+func (m *Mock[T, ID]) Instance() *instance[T, ID] {
+	return &m.instance
+}
+
+type getMethodMatcher[T any, ID comparable] struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *getMethodMatcher[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+// Get gets the item with the specified ID.
+func Get[T any, ID comparable, P0 mocking.Matcher[ID]](id P0) *getMethodMatcher[T, ID] {
+	result := getMethodMatcher[T, ID]{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Get",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(id).(mocking.Matcher[ID]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(id).(ID))
+	}
+
+	return &result
+}
+
+type getTimes[T any, ID comparable] struct {
+	matcher *getMethodMatcher[T, ID]
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *getMethodMatcher[T, ID]) Times(times uint) *getTimes[T, ID] {
+	m.matcher.Times = &times
+
+	return &getTimes[T, ID]{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *getMethodMatcher[T, ID]) Once() *getTimes[T, ID] {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *getMethodMatcher[T, ID]) Never() *getTimes[T, ID] {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *getTimes[T, ID]) Return(r0 T, r1 error) *getAction[T, ID] {
+	return &getAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *getTimes[T, ID]) Panic(arg any) *getAction[T, ID] {
+	return &getAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *getTimes[T, ID]) When(observe func(id ID) (T, error)) *getAction[T, ID] {
+	return &getAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *getTimes[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *getMethodMatcher[T, ID]) Return(r0 T, r1 error) *getAction[T, ID] {
+	return &getAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *getMethodMatcher[T, ID]) Panic(arg any) *getAction[T, ID] {
+	return &getAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *getMethodMatcher[T, ID]) When(observe func(id ID) (T, error)) *getAction[T, ID] {
+	return &getAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type getAction[T any, ID comparable] struct {
+	expectation mocking.Expectation
+}
+
+func (a *getAction[T, ID]) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type findByNameMethodMatcher[T any, ID comparable] struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *findByNameMethodMatcher[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func FindByName[T any, ID comparable, P0 string | mocking.Matcher[string]](name P0) *findByNameMethodMatcher[T, ID] {
+	result := findByNameMethodMatcher[T, ID]{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "FindByName",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(name).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(name).(string))
+	}
+
+	return &result
+}
+
+type findByNameTimes[T any, ID comparable] struct {
+	matcher *findByNameMethodMatcher[T, ID]
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *findByNameMethodMatcher[T, ID]) Times(times uint) *findByNameTimes[T, ID] {
+	m.matcher.Times = &times
+
+	return &findByNameTimes[T, ID]{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *findByNameMethodMatcher[T, ID]) Once() *findByNameTimes[T, ID] {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *findByNameMethodMatcher[T, ID]) Never() *findByNameTimes[T, ID] {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *findByNameTimes[T, ID]) Return(r0 []T, r1 error) *findByNameAction[T, ID] {
+	return &findByNameAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *findByNameTimes[T, ID]) Panic(arg any) *findByNameAction[T, ID] {
+	return &findByNameAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *findByNameTimes[T, ID]) When(observe func(name string) ([]T, error)) *findByNameAction[T, ID] {
+	return &findByNameAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *findByNameTimes[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *findByNameMethodMatcher[T, ID]) Return(r0 []T, r1 error) *findByNameAction[T, ID] {
+	return &findByNameAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *findByNameMethodMatcher[T, ID]) Panic(arg any) *findByNameAction[T, ID] {
+	return &findByNameAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *findByNameMethodMatcher[T, ID]) When(observe func(name string) ([]T, error)) *findByNameAction[T, ID] {
+	return &findByNameAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type findByNameAction[T any, ID comparable] struct {
+	expectation mocking.Expectation
+}
+
+func (a *findByNameAction[T, ID]) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type saveMethodMatcher[T any, ID comparable] struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *saveMethodMatcher[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Save[T any, ID comparable, P0 mocking.Matcher[T]](item P0) *saveMethodMatcher[T, ID] {
+	result := saveMethodMatcher[T, ID]{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Save",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(item).(mocking.Matcher[T]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(item).(T))
+	}
+
+	return &result
+}
+
+type saveTimes[T any, ID comparable] struct {
+	matcher *saveMethodMatcher[T, ID]
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *saveMethodMatcher[T, ID]) Times(times uint) *saveTimes[T, ID] {
+	m.matcher.Times = &times
+
+	return &saveTimes[T, ID]{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *saveMethodMatcher[T, ID]) Once() *saveTimes[T, ID] {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *saveMethodMatcher[T, ID]) Never() *saveTimes[T, ID] {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *saveTimes[T, ID]) Return(r0 error) *saveAction[T, ID] {
+	return &saveAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *saveTimes[T, ID]) Panic(arg any) *saveAction[T, ID] {
+	return &saveAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *saveTimes[T, ID]) When(observe func(item T) error) *saveAction[T, ID] {
+	return &saveAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *saveTimes[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *saveMethodMatcher[T, ID]) Return(r0 error) *saveAction[T, ID] {
+	return &saveAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *saveMethodMatcher[T, ID]) Panic(arg any) *saveAction[T, ID] {
+	return &saveAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *saveMethodMatcher[T, ID]) When(observe func(item T) error) *saveAction[T, ID] {
+	return &saveAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type saveAction[T any, ID comparable] struct {
+	expectation mocking.Expectation
+}
+
+func (a *saveAction[T, ID]) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type countMethodMatcher[T any, ID comparable] struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *countMethodMatcher[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Count[T any, ID comparable]() *countMethodMatcher[T, ID] {
+	result := countMethodMatcher[T, ID]{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Count",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type countTimes[T any, ID comparable] struct {
+	matcher *countMethodMatcher[T, ID]
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *countMethodMatcher[T, ID]) Times(times uint) *countTimes[T, ID] {
+	m.matcher.Times = &times
+
+	return &countTimes[T, ID]{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *countMethodMatcher[T, ID]) Once() *countTimes[T, ID] {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *countMethodMatcher[T, ID]) Never() *countTimes[T, ID] {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *countTimes[T, ID]) Return(r0 int) *countAction[T, ID] {
+	return &countAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *countTimes[T, ID]) Panic(arg any) *countAction[T, ID] {
+	return &countAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *countTimes[T, ID]) When(observe func() int) *countAction[T, ID] {
+	return &countAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *countTimes[T, ID]) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *countMethodMatcher[T, ID]) Return(r0 int) *countAction[T, ID] {
+	return &countAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *countMethodMatcher[T, ID]) Panic(arg any) *countAction[T, ID] {
+	return &countAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *countMethodMatcher[T, ID]) When(observe func() int) *countAction[T, ID] {
+	return &countAction[T, ID]{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type countAction[T any, ID comparable] struct {
+	expectation mocking.Expectation
+}
+
+func (a *countAction[T, ID]) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -63,7 +63,7 @@ func (m *getSecretMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
 	return &m.matcher
 }
 
-func GetSecret[P0 mocking.Matcher[context.Context], P1 string | mocking.Matcher[string], P2 func(*secretsmanager.GetSecretOptions) | mocking.Matcher[func(*secretsmanager.GetSecretOptions)]](ctx P0, name P1, opts ...P2) *getSecretMethodMatcher {
+func GetSecret[P1 string | mocking.Matcher[string]](ctx any, name P1, opts ...any) *getSecretMethodMatcher {
 	result := getSecretMethodMatcher{
 		matcher: mocking.MethodMatcher{
 			MethodName:       "GetSecret",
@@ -71,11 +71,7 @@ func GetSecret[P0 mocking.Matcher[context.Context], P1 string | mocking.Matcher[
 		},
 	}
 
-	if matcher, ok := any(ctx).(mocking.Matcher[context.Context]); ok {
-		result.matcher.ArgumentMatchers[0] = matcher
-	} else {
-		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(ctx).(context.Context))
-	}
+	result.matcher.ArgumentMatchers[0] = mocking.InterfaceMatcher[context.Context](ctx)
 
 	if matcher, ok := any(name).(mocking.Matcher[string]); ok {
 		result.matcher.ArgumentMatchers[1] = matcher
@@ -85,10 +81,10 @@ func GetSecret[P0 mocking.Matcher[context.Context], P1 string | mocking.Matcher[
 
 	var matchers []mocking.ArgumentMatcher
 	for _, arg := range opts {
-		if matcher, ok := any(arg).(mocking.ArgumentMatcher); ok {
+		if matcher, ok := arg.(mocking.ArgumentMatcher); ok {
 			matchers = append(matchers, matcher)
 		} else {
-			matchers = append(matchers, kelpie.ExactMatch(any(arg).(func(*secretsmanager.GetSecretOptions))))
+			matchers = append(matchers, mocking.ValueMatcher[func(*secretsmanager.GetSecretOptions)](arg))
 		}
 	}
 
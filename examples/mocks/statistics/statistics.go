@@ -0,0 +1,320 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package statistics
+
+import (
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Sum(values ...int64) (r0 int64) {
+	expectation := m.mock.Call("Sum", values)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(values ...int64) int64)
+			return observe(values...)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(int64)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Average(values ...float64) (r0 float64) {
+	expectation := m.mock.Call("Average", values)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(values ...float64) float64)
+			return observe(values...)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(float64)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type sumMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *sumMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Sum(values ...any) *sumMethodMatcher {
+	result := sumMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Sum",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	var matchers []mocking.ArgumentMatcher
+	for _, arg := range values {
+		if matcher, ok := arg.(mocking.ArgumentMatcher); ok {
+			matchers = append(matchers, matcher)
+		} else {
+			matchers = append(matchers, mocking.ValueMatcher[int64](arg))
+		}
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.Variadic(matchers)
+
+	return &result
+}
+
+type sumTimes struct {
+	matcher *sumMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *sumMethodMatcher) Times(times uint) *sumTimes {
+	m.matcher.Times = &times
+
+	return &sumTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *sumMethodMatcher) Once() *sumTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *sumMethodMatcher) Never() *sumTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *sumTimes) Return(r0 int64) *sumAction {
+	return &sumAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *sumTimes) Panic(arg any) *sumAction {
+	return &sumAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *sumTimes) When(observe func(values ...int64) int64) *sumAction {
+	return &sumAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *sumTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *sumMethodMatcher) Return(r0 int64) *sumAction {
+	return &sumAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *sumMethodMatcher) Panic(arg any) *sumAction {
+	return &sumAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *sumMethodMatcher) When(observe func(values ...int64) int64) *sumAction {
+	return &sumAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type sumAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *sumAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type averageMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *averageMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Average(values ...any) *averageMethodMatcher {
+	result := averageMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Average",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	var matchers []mocking.ArgumentMatcher
+	for _, arg := range values {
+		if matcher, ok := arg.(mocking.ArgumentMatcher); ok {
+			matchers = append(matchers, matcher)
+		} else {
+			matchers = append(matchers, mocking.ValueMatcher[float64](arg))
+		}
+	}
+
+	result.matcher.ArgumentMatchers[0] = mocking.Variadic(matchers)
+
+	return &result
+}
+
+type averageTimes struct {
+	matcher *averageMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *averageMethodMatcher) Times(times uint) *averageTimes {
+	m.matcher.Times = &times
+
+	return &averageTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *averageMethodMatcher) Once() *averageTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *averageMethodMatcher) Never() *averageTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *averageTimes) Return(r0 float64) *averageAction {
+	return &averageAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *averageTimes) Panic(arg any) *averageAction {
+	return &averageAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *averageTimes) When(observe func(values ...float64) float64) *averageAction {
+	return &averageAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *averageTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *averageMethodMatcher) Return(r0 float64) *averageAction {
+	return &averageAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *averageMethodMatcher) Panic(arg any) *averageAction {
+	return &averageAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *averageMethodMatcher) When(observe func(values ...float64) float64) *averageAction {
+	return &averageAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type averageAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *averageAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,628 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package transformer
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	examplesmocking "github.com/adamconnelly/kelpie/examples/mocking"
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Apply(m1 int, expectation1 string, result1 int) (mocking1 string, t1 error) {
+	expectation := m.mock.Call("Apply", m1, expectation1, result1)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(m1 int, expectation1 string, result1 int) (string, error))
+			return observe(m1, expectation1, result1)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			mocking1 = expectation.Returns[0].(string)
+		}
+
+		if expectation.Returns[1] != nil {
+			t1 = expectation.Returns[1].(error)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Lookup(users1 users.User, kelpie1 int) (r0 int, r1 bool) {
+	expectation := m.mock.Call("Lookup", users1, kelpie1)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(users1 users.User, kelpie1 int) (int, bool))
+			return observe(users1, kelpie1)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(int)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(bool)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Convert(r0 string) (r0_1 string) {
+	expectation := m.mock.Call("Convert", r0)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(r0 string) string)
+			return observe(r0)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0_1 = expectation.Returns[0].(string)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Configure(options examplesmocking.Options, P0_1 int) (r0 error) {
+	expectation := m.mock.Call("Configure", options, P0_1)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(options examplesmocking.Options, P0_1 int) error)
+			return observe(options, P0_1)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type applyMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *applyMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Apply[P0 int | mocking.Matcher[int], P1 string | mocking.Matcher[string], P2 int | mocking.Matcher[int]](m1 P0, expectation1 P1, result1 P2) *applyMethodMatcher {
+	result := applyMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Apply",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 3),
+		},
+	}
+
+	if matcher, ok := any(m1).(mocking.Matcher[int]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(m1).(int))
+	}
+
+	if matcher, ok := any(expectation1).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(expectation1).(string))
+	}
+
+	if matcher, ok := any(result1).(mocking.Matcher[int]); ok {
+		result.matcher.ArgumentMatchers[2] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[2] = kelpie.ExactMatch(any(result1).(int))
+	}
+
+	return &result
+}
+
+type applyTimes struct {
+	matcher *applyMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *applyMethodMatcher) Times(times uint) *applyTimes {
+	m.matcher.Times = &times
+
+	return &applyTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *applyMethodMatcher) Once() *applyTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *applyMethodMatcher) Never() *applyTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *applyTimes) Return(mocking1 string, t1 error) *applyAction {
+	return &applyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{mocking1, t1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *applyTimes) Panic(arg any) *applyAction {
+	return &applyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *applyTimes) When(observe func(m1 int, expectation1 string, result1 int) (string, error)) *applyAction {
+	return &applyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *applyTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *applyMethodMatcher) Return(mocking1 string, t1 error) *applyAction {
+	return &applyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{mocking1, t1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *applyMethodMatcher) Panic(arg any) *applyAction {
+	return &applyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *applyMethodMatcher) When(observe func(m1 int, expectation1 string, result1 int) (string, error)) *applyAction {
+	return &applyAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type applyAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *applyAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type lookupMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *lookupMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Lookup[P0 users.User | mocking.Matcher[users.User], P1 int | mocking.Matcher[int]](users1 P0, kelpie1 P1) *lookupMethodMatcher {
+	result := lookupMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Lookup",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	if matcher, ok := any(users1).(mocking.Matcher[users.User]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(users1).(users.User))
+	}
+
+	if matcher, ok := any(kelpie1).(mocking.Matcher[int]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(kelpie1).(int))
+	}
+
+	return &result
+}
+
+type lookupTimes struct {
+	matcher *lookupMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *lookupMethodMatcher) Times(times uint) *lookupTimes {
+	m.matcher.Times = &times
+
+	return &lookupTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *lookupMethodMatcher) Once() *lookupTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *lookupMethodMatcher) Never() *lookupTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *lookupTimes) Return(r0 int, r1 bool) *lookupAction {
+	return &lookupAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *lookupTimes) Panic(arg any) *lookupAction {
+	return &lookupAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *lookupTimes) When(observe func(users1 users.User, kelpie1 int) (int, bool)) *lookupAction {
+	return &lookupAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *lookupTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *lookupMethodMatcher) Return(r0 int, r1 bool) *lookupAction {
+	return &lookupAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *lookupMethodMatcher) Panic(arg any) *lookupAction {
+	return &lookupAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *lookupMethodMatcher) When(observe func(users1 users.User, kelpie1 int) (int, bool)) *lookupAction {
+	return &lookupAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type lookupAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *lookupAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type convertMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *convertMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Convert[P0 string | mocking.Matcher[string]](r0 P0) *convertMethodMatcher {
+	result := convertMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Convert",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(r0).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(r0).(string))
+	}
+
+	return &result
+}
+
+type convertTimes struct {
+	matcher *convertMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *convertMethodMatcher) Times(times uint) *convertTimes {
+	m.matcher.Times = &times
+
+	return &convertTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *convertMethodMatcher) Once() *convertTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *convertMethodMatcher) Never() *convertTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *convertTimes) Return(r0_1 string) *convertAction {
+	return &convertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0_1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *convertTimes) Panic(arg any) *convertAction {
+	return &convertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *convertTimes) When(observe func(r0 string) string) *convertAction {
+	return &convertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *convertTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *convertMethodMatcher) Return(r0_1 string) *convertAction {
+	return &convertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0_1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *convertMethodMatcher) Panic(arg any) *convertAction {
+	return &convertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *convertMethodMatcher) When(observe func(r0 string) string) *convertAction {
+	return &convertAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type convertAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *convertAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type configureMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *configureMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Configure[P0 examplesmocking.Options | mocking.Matcher[examplesmocking.Options], P1 int | mocking.Matcher[int]](options P0, P0_1 P1) *configureMethodMatcher {
+	result := configureMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Configure",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	if matcher, ok := any(options).(mocking.Matcher[examplesmocking.Options]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(options).(examplesmocking.Options))
+	}
+
+	if matcher, ok := any(P0_1).(mocking.Matcher[int]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(P0_1).(int))
+	}
+
+	return &result
+}
+
+type configureTimes struct {
+	matcher *configureMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *configureMethodMatcher) Times(times uint) *configureTimes {
+	m.matcher.Times = &times
+
+	return &configureTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *configureMethodMatcher) Once() *configureTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *configureMethodMatcher) Never() *configureTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *configureTimes) Return(r0 error) *configureAction {
+	return &configureAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *configureTimes) Panic(arg any) *configureAction {
+	return &configureAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *configureTimes) When(observe func(options examplesmocking.Options, P0_1 int) error) *configureAction {
+	return &configureAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *configureTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *configureMethodMatcher) Return(r0 error) *configureAction {
+	return &configureAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *configureMethodMatcher) Panic(arg any) *configureAction {
+	return &configureAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *configureMethodMatcher) When(observe func(options examplesmocking.Options, P0_1 int) error) *configureAction {
+	return &configureAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type configureAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *configureAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,300 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package usercache
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type Mock struct {
+	mocking.Mock
+	instance instance
+}
+
+func NewMock() *Mock {
+	mock := Mock{
+		instance: instance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type instance struct {
+	mock *Mock
+}
+
+func (m *instance) Get(key string) (r0 users.User, r1 bool) {
+	expectation := m.mock.Call("Get", key)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(key string) (users.User, bool))
+			return observe(key)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(users.User)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(bool)
+		}
+	}
+
+	return
+}
+
+func (m *instance) Set(key string, value users.User) {
+	expectation := m.mock.Call("Set", key, value)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(key string, value users.User))
+			observe(key, value)
+			return
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+	}
+
+	return
+}
+
+func (m *Mock) Instance() *instance {
+	return &m.instance
+}
+
+type getMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *getMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Get[P0 string | mocking.Matcher[string]](key P0) *getMethodMatcher {
+	result := getMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Get",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(key).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(key).(string))
+	}
+
+	return &result
+}
+
+type getTimes struct {
+	matcher *getMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *getMethodMatcher) Times(times uint) *getTimes {
+	m.matcher.Times = &times
+
+	return &getTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *getMethodMatcher) Once() *getTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *getMethodMatcher) Never() *getTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *getTimes) Return(r0 users.User, r1 bool) *getAction {
+	return &getAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *getTimes) Panic(arg any) *getAction {
+	return &getAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *getTimes) When(observe func(key string) (users.User, bool)) *getAction {
+	return &getAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *getTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *getMethodMatcher) Return(r0 users.User, r1 bool) *getAction {
+	return &getAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *getMethodMatcher) Panic(arg any) *getAction {
+	return &getAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *getMethodMatcher) When(observe func(key string) (users.User, bool)) *getAction {
+	return &getAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type getAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *getAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type setMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *setMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func Set[P0 string | mocking.Matcher[string], P1 users.User | mocking.Matcher[users.User]](key P0, value P1) *setMethodMatcher {
+	result := setMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Set",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 2),
+		},
+	}
+
+	if matcher, ok := any(key).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(key).(string))
+	}
+
+	if matcher, ok := any(value).(mocking.Matcher[users.User]); ok {
+		result.matcher.ArgumentMatchers[1] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[1] = kelpie.ExactMatch(any(value).(users.User))
+	}
+
+	return &result
+}
+
+type setTimes struct {
+	matcher *setMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *setMethodMatcher) Times(times uint) *setTimes {
+	m.matcher.Times = &times
+
+	return &setTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *setMethodMatcher) Once() *setTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *setMethodMatcher) Never() *setTimes {
+	return m.Times(0)
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *setTimes) Panic(arg any) *setAction {
+	return &setAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *setTimes) When(observe func(key string, value users.User)) *setAction {
+	return &setAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *setTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *setMethodMatcher) Panic(arg any) *setAction {
+	return &setAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *setMethodMatcher) When(observe func(key string, value users.User)) *setAction {
+	return &setAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type setAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *setAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,305 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package examples
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+)
+
+type reminderStoreMock struct {
+	mocking.Mock
+	instance reminderStoreInstance
+}
+
+func newReminderStoreMock() *reminderStoreMock {
+	mock := reminderStoreMock{
+		instance: reminderStoreInstance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type reminderStoreInstance struct {
+	mock *reminderStoreMock
+}
+
+func (m *reminderStoreInstance) save(r reminder) (r0 error) {
+	expectation := m.mock.Call("save", r)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(r reminder) error)
+			return observe(r)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(error)
+		}
+	}
+
+	return
+}
+
+func (m *reminderStoreInstance) dueReminders() (r0 []reminder) {
+	expectation := m.mock.Call("dueReminders")
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func() []reminder)
+			return observe()
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].([]reminder)
+		}
+	}
+
+	return
+}
+
+func (m *reminderStoreMock) Instance() *reminderStoreInstance {
+	return &m.instance
+}
+
+type reminderStoreSaveMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *reminderStoreSaveMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func reminderStoreSave[P0 reminder | mocking.Matcher[reminder]](r P0) *reminderStoreSaveMethodMatcher {
+	result := reminderStoreSaveMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "save",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(r).(mocking.Matcher[reminder]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(r).(reminder))
+	}
+
+	return &result
+}
+
+type reminderStoreSaveTimes struct {
+	matcher *reminderStoreSaveMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *reminderStoreSaveMethodMatcher) Times(times uint) *reminderStoreSaveTimes {
+	m.matcher.Times = &times
+
+	return &reminderStoreSaveTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *reminderStoreSaveMethodMatcher) Once() *reminderStoreSaveTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *reminderStoreSaveMethodMatcher) Never() *reminderStoreSaveTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *reminderStoreSaveTimes) Return(r0 error) *reminderStoreSaveAction {
+	return &reminderStoreSaveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *reminderStoreSaveTimes) Panic(arg any) *reminderStoreSaveAction {
+	return &reminderStoreSaveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *reminderStoreSaveTimes) When(observe func(r reminder) error) *reminderStoreSaveAction {
+	return &reminderStoreSaveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *reminderStoreSaveTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *reminderStoreSaveMethodMatcher) Return(r0 error) *reminderStoreSaveAction {
+	return &reminderStoreSaveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *reminderStoreSaveMethodMatcher) Panic(arg any) *reminderStoreSaveAction {
+	return &reminderStoreSaveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *reminderStoreSaveMethodMatcher) When(observe func(r reminder) error) *reminderStoreSaveAction {
+	return &reminderStoreSaveAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type reminderStoreSaveAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *reminderStoreSaveAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
+
+type reminderStoreDueRemindersMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *reminderStoreDueRemindersMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func reminderStoreDueReminders() *reminderStoreDueRemindersMethodMatcher {
+	result := reminderStoreDueRemindersMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "dueReminders",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 0),
+		},
+	}
+
+	return &result
+}
+
+type reminderStoreDueRemindersTimes struct {
+	matcher *reminderStoreDueRemindersMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *reminderStoreDueRemindersMethodMatcher) Times(times uint) *reminderStoreDueRemindersTimes {
+	m.matcher.Times = &times
+
+	return &reminderStoreDueRemindersTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *reminderStoreDueRemindersMethodMatcher) Once() *reminderStoreDueRemindersTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *reminderStoreDueRemindersMethodMatcher) Never() *reminderStoreDueRemindersTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *reminderStoreDueRemindersTimes) Return(r0 []reminder) *reminderStoreDueRemindersAction {
+	return &reminderStoreDueRemindersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *reminderStoreDueRemindersTimes) Panic(arg any) *reminderStoreDueRemindersAction {
+	return &reminderStoreDueRemindersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *reminderStoreDueRemindersTimes) When(observe func() []reminder) *reminderStoreDueRemindersAction {
+	return &reminderStoreDueRemindersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *reminderStoreDueRemindersTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *reminderStoreDueRemindersMethodMatcher) Return(r0 []reminder) *reminderStoreDueRemindersAction {
+	return &reminderStoreDueRemindersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *reminderStoreDueRemindersMethodMatcher) Panic(arg any) *reminderStoreDueRemindersAction {
+	return &reminderStoreDueRemindersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *reminderStoreDueRemindersMethodMatcher) When(observe func() []reminder) *reminderStoreDueRemindersAction {
+	return &reminderStoreDueRemindersAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type reminderStoreDueRemindersAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *reminderStoreDueRemindersAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
@@ -0,0 +1,60 @@
+package examples
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/billing"
+	"github.com/adamconnelly/kelpie/examples/billing/mocks/client"
+)
+
+type CheckoutService struct {
+	billing client.Client
+}
+
+func (s *CheckoutService) Checkout(ctx context.Context, customerID string, amount int) (string, error) {
+	charge, err := s.billing.Charge(ctx, customerID, amount)
+	if err != nil {
+		return "", err
+	}
+
+	return charge.ID, nil
+}
+
+type StructMethodSetsTests struct {
+	suite.Suite
+}
+
+func (t *StructMethodSetsTests) Test_GeneratedInterfaceIsImplementedByTheStruct() {
+	// Arrange
+	var billingClient client.Client = billing.NewClient(nil, "https://billing.example.com")
+
+	// Act
+	baseURL := billingClient.BaseURL()
+
+	// Assert
+	t.Equal("https://billing.example.com", baseURL)
+}
+
+func (t *StructMethodSetsTests) Test_CanMockStructMethods() {
+	// Arrange
+	mock := client.NewMock()
+	mock.Setup(client.Charge(kelpie.Any[context.Context](), "customer-123", 500).Return(&billing.Charge{ID: "charge-456"}, nil))
+
+	service := CheckoutService{billing: mock.Instance()}
+
+	// Act
+	chargeID, err := service.Checkout(context.Background(), "customer-123", 500)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("charge-456", chargeID)
+	t.True(mock.Called(client.Charge(kelpie.Any[context.Context](), "customer-123", 500).Once()))
+}
+
+func TestStructMethodSets(t *testing.T) {
+	suite.Run(t, new(StructMethodSetsTests))
+}
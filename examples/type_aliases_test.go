@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/examples/mocks/bytestream"
+)
+
+// ByteStream is an alias to an interface from another package. Kelpie generates a mock
+// containing the method set of the aliased interface.
+type ByteStream = io.ReadWriteCloser
+
+type TypeAliasesTests struct {
+	suite.Suite
+}
+
+func (t *TypeAliasesTests) Test_CanMockAliasedInterface() {
+	// Arrange
+	mock := bytestream.NewMock()
+	mock.Setup(bytestream.Write(kelpie.Any[[]byte]()).Return(0, errors.New("stream closed")))
+
+	var stream ByteStream = mock.Instance()
+
+	// Act
+	_, err := stream.Write([]byte("Hello World"))
+	stream.Close()
+
+	// Assert
+	t.ErrorContains(err, "stream closed")
+	t.True(mock.Called(bytestream.Close().Once()))
+}
+
+func TestTypeAliases(t *testing.T) {
+	suite.Run(t, new(TypeAliasesTests))
+}
@@ -0,0 +1,176 @@
+// Code generated by Kelpie. DO NOT EDIT.
+package users_test
+
+import (
+	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/mocking"
+
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+type userCacheMock struct {
+	mocking.Mock
+	instance userCacheInstance
+}
+
+func newUserCacheMock() *userCacheMock {
+	mock := userCacheMock{
+		instance: userCacheInstance{},
+	}
+	mock.instance.mock = &mock
+
+	return &mock
+}
+
+type userCacheInstance struct {
+	mock *userCacheMock
+}
+
+func (m *userCacheInstance) Get(username string) (r0 *users.User, r1 bool) {
+	expectation := m.mock.Call("Get", username)
+	if expectation != nil {
+		if expectation.ObserveFn != nil {
+			observe := expectation.ObserveFn.(func(username string) (*users.User, bool))
+			return observe(username)
+		}
+
+		if expectation.PanicArg != nil {
+			panic(expectation.PanicArg)
+		}
+
+		if expectation.Returns[0] != nil {
+			r0 = expectation.Returns[0].(*users.User)
+		}
+
+		if expectation.Returns[1] != nil {
+			r1 = expectation.Returns[1].(bool)
+		}
+	}
+
+	return
+}
+
+func (m *userCacheMock) Instance() *userCacheInstance {
+	return &m.instance
+}
+
+type userCacheGetMethodMatcher struct {
+	matcher mocking.MethodMatcher
+}
+
+func (m *userCacheGetMethodMatcher) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &m.matcher
+}
+
+func userCacheGet[P0 string | mocking.Matcher[string]](username P0) *userCacheGetMethodMatcher {
+	result := userCacheGetMethodMatcher{
+		matcher: mocking.MethodMatcher{
+			MethodName:       "Get",
+			ArgumentMatchers: make([]mocking.ArgumentMatcher, 1),
+		},
+	}
+
+	if matcher, ok := any(username).(mocking.Matcher[string]); ok {
+		result.matcher.ArgumentMatchers[0] = matcher
+	} else {
+		result.matcher.ArgumentMatchers[0] = kelpie.ExactMatch(any(username).(string))
+	}
+
+	return &result
+}
+
+type userCacheGetTimes struct {
+	matcher *userCacheGetMethodMatcher
+}
+
+// Times allows you to restrict the number of times a particular expectation can be matched.
+func (m *userCacheGetMethodMatcher) Times(times uint) *userCacheGetTimes {
+	m.matcher.Times = &times
+
+	return &userCacheGetTimes{
+		matcher: m,
+	}
+}
+
+// Once specifies that the expectation will only match once.
+func (m *userCacheGetMethodMatcher) Once() *userCacheGetTimes {
+	return m.Times(1)
+}
+
+// Never specifies that the method has not been called. This is mainly useful for verification
+// rather than mocking.
+func (m *userCacheGetMethodMatcher) Never() *userCacheGetTimes {
+	return m.Times(0)
+}
+
+// Return returns the specified results when the method is called.
+func (t *userCacheGetTimes) Return(r0 *users.User, r1 bool) *userCacheGetAction {
+	return &userCacheGetAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (t *userCacheGetTimes) Panic(arg any) *userCacheGetAction {
+	return &userCacheGetAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (t *userCacheGetTimes) When(observe func(username string) (*users.User, bool)) *userCacheGetAction {
+	return &userCacheGetAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &t.matcher.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+func (t *userCacheGetTimes) CreateMethodMatcher() *mocking.MethodMatcher {
+	return &t.matcher.matcher
+}
+
+// Return returns the specified results when the method is called.
+func (m *userCacheGetMethodMatcher) Return(r0 *users.User, r1 bool) *userCacheGetAction {
+	return &userCacheGetAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			Returns:       []any{r0, r1},
+		},
+	}
+}
+
+// Panic panics using the specified argument when the method is called.
+func (m *userCacheGetMethodMatcher) Panic(arg any) *userCacheGetAction {
+	return &userCacheGetAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			PanicArg:      arg,
+		},
+	}
+}
+
+// When calls the specified observe callback when the method is called.
+func (m *userCacheGetMethodMatcher) When(observe func(username string) (*users.User, bool)) *userCacheGetAction {
+	return &userCacheGetAction{
+		expectation: mocking.Expectation{
+			MethodMatcher: &m.matcher,
+			ObserveFn:     observe,
+		},
+	}
+}
+
+type userCacheGetAction struct {
+	expectation mocking.Expectation
+}
+
+func (a *userCacheGetAction) CreateExpectation() *mocking.Expectation {
+	return &a.expectation
+}
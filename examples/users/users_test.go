@@ -0,0 +1,48 @@
+package users_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/examples/users"
+)
+
+// userCache is only declared in the external test package, so its mock is generated in a
+// _test.go file in this package.
+type userCache interface {
+	Get(username string) (*users.User, bool)
+}
+
+type admins struct {
+	cache userCache
+}
+
+func (a *admins) IsAdmin(username string) bool {
+	user, ok := a.cache.Get(username)
+
+	return ok && user.Type == users.UserTypeAdmin
+}
+
+type ExternalTestPackageTests struct {
+	suite.Suite
+}
+
+func (t *ExternalTestPackageTests) Test_CanMockInterfacesInExternalTestPackages() {
+	// Arrange
+	mock := newUserCacheMock()
+	mock.Setup(userCacheGet("adam").Return(&users.User{Username: "adam", Type: users.UserTypeAdmin}, true))
+
+	a := admins{cache: mock.Instance()}
+
+	// Act
+	isAdmin := a.IsAdmin("adam")
+
+	// Assert
+	t.True(isAdmin)
+	t.True(mock.Called(userCacheGet("adam").Once()))
+}
+
+func TestExternalTestPackage(t *testing.T) {
+	suite.Run(t, new(ExternalTestPackageTests))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/adamconnelly/kelpie"
 	"github.com/adamconnelly/kelpie/examples/mocks/printer"
 	sm "github.com/adamconnelly/kelpie/examples/mocks/secretsmanager"
+	"github.com/adamconnelly/kelpie/examples/mocks/statistics"
 	"github.com/adamconnelly/kelpie/examples/secretsmanager"
 )
 
@@ -20,6 +21,11 @@ type Printer interface {
 	Printf(formatString string, args ...interface{}) string
 }
 
+type Statistics interface {
+	Sum(values ...int64) int64
+	Average(values ...float64) float64
+}
+
 func (t *VariadicFunctionsTests) Test_Parameters_ExactMatch() {
 	// Arrange
 	mock := printer.NewMock()
@@ -47,6 +53,66 @@ func (t *VariadicFunctionsTests) Test_Parameters_AnyMatch() {
 	t.Equal("Hello Dolly. This is Louis, Dolly.", result)
 }
 
+func (t *VariadicFunctionsTests) Test_Parameters_MixExactAndCustomMatching() {
+	// Arrange
+	mock := printer.NewMock()
+
+	mock.Setup(printer.Printf("Hello %s. This is %s, %s.", "Dolly", kelpie.Any[string](), "Dolly").
+		Return("Hello Dolly. This is Louis, Dolly."))
+
+	// Act
+	result1 := mock.Instance().Printf("Hello %s. This is %s, %s.", "Dolly", "Rab", "Dolly")
+	result2 := mock.Instance().Printf("Hello %s. This is %s, %s.", "Dolly", "Rab", "Rab")
+
+	// Assert
+	t.Equal("Hello Dolly. This is Louis, Dolly.", result1)
+	t.Equal("", result2)
+}
+
+func (t *VariadicFunctionsTests) Test_Parameters_MatchPrefix() {
+	// Arrange
+	mock := printer.NewMock()
+
+	mock.Setup(printer.Printf("Hello %s. This is %s, %s.", "Dolly", "Louis", kelpie.AnyArgs[any]()).
+		Return("Hello Dolly. This is Louis."))
+
+	// Act
+	result1 := mock.Instance().Printf("Hello %s. This is %s, %s.", "Dolly", "Louis")
+	result2 := mock.Instance().Printf("Hello %s. This is %s, %s.", "Dolly", "Louis", "Dolly")
+	result3 := mock.Instance().Printf("Hello %s. This is %s, %s.", "Louis", "Dolly")
+
+	// Assert
+	t.Equal("Hello Dolly. This is Louis.", result1)
+	t.Equal("Hello Dolly. This is Louis.", result2)
+	t.Equal("", result3)
+}
+
+func (t *VariadicFunctionsTests) Test_Parameters_MatchSuffix() {
+	// Arrange
+	mock := printer.NewMock()
+
+	// Act
+	mock.Instance().Printf("%d %d %d", 1, 2, 3)
+
+	// Assert
+	t.True(mock.Called(printer.Printf("%d %d %d", kelpie.AnyArgs[any](), 3)))
+	t.True(mock.Called(printer.Printf("%d %d %d", kelpie.AnyArgs[any](), 2, 3)))
+	t.True(mock.Called(printer.Printf("%d %d %d", 1, kelpie.AnyArgs[any](), 3)))
+	t.False(mock.Called(printer.Printf("%d %d %d", kelpie.AnyArgs[any](), 2)))
+}
+
+func (t *VariadicFunctionsTests) Test_Parameters_NoArgumentsMatchesEmptyList() {
+	// Arrange
+	mock := printer.NewMock()
+
+	// Act
+	mock.Instance().Printf("Nothing to say")
+
+	// Assert
+	t.True(mock.Called(printer.Printf("Nothing to say")))
+	t.False(mock.Called(printer.Printf("Nothing to say", "Hello")))
+}
+
 func (t *VariadicFunctionsTests) Test_Parameters_NoneProvided() {
 	// Arrange
 	mock := printer.NewMock()
@@ -119,8 +185,8 @@ func (t *VariadicFunctionsTests) Test_Parameters_Called() {
 	mock.Instance().Printf("Hello world!", "One", 2, 3.0)
 
 	// Assert
-	t.True(mock.Called(printer.Printf[string, any]("Hello world!", "One", 2, 3.0)))
-	t.False(mock.Called(printer.Printf[string, any]("Hello world!", "Five", 2, 3.0)))
+	t.True(mock.Called(printer.Printf("Hello world!", "One", 2, 3.0)))
+	t.False(mock.Called(printer.Printf("Hello world!", "Five", 2, 3.0)))
 }
 
 func (t *VariadicFunctionsTests) Test_OptionsStyleFunction() {
@@ -147,6 +213,22 @@ func (t *VariadicFunctionsTests) Test_OptionsStyleFunction() {
 	t.Equal("version123", *options.Version)
 }
 
+func (t *VariadicFunctionsTests) Test_Parameters_ConvertsUntypedConstants() {
+	// Arrange
+	mock := statistics.NewMock()
+
+	mock.Setup(statistics.Sum(1, 2, 3).Return(6))
+	mock.Setup(statistics.Average(1, 2.5).Return(1.75))
+
+	// Act
+	sum := mock.Instance().Sum(1, 2, 3)
+	average := mock.Instance().Average(1, 2.5)
+
+	// Assert
+	t.Equal(int64(6), sum)
+	t.Equal(1.75, average)
+}
+
 func TestVariadicFunctions(t *testing.T) {
 	suite.Run(t, new(VariadicFunctionsTests))
 }
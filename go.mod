@@ -1,6 +1,6 @@
 module github.com/adamconnelly/kelpie
 
-go 1.21
+go 1.22
 
 require (
 	github.com/alecthomas/kong v0.8.1
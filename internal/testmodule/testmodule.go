@@ -0,0 +1,27 @@
+// Package testmodule writes Go modules to disk so that tests can load and parse them.
+package testmodule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Path is the module path of the modules created by Write.
+const Path = "github.com/adamconnelly/kelpie-test"
+
+// Write creates a module in a temporary directory containing the specified files, and returns the
+// module's directory. The keys of files are paths relative to the root of the module.
+func Write(t testing.TB, files map[string]string) string {
+	moduleDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module "+Path+"\n\ngo 1.22.1"), 0600))
+
+	for name, input := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(moduleDir, name)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, name), []byte(input), 0600))
+	}
+
+	return moduleDir
+}
@@ -36,6 +36,11 @@ func None[T any]() mocking.Matcher[T] {
 // care what arguments are passed as the variable parameter. For example:
 //
 // printMock.Setup(print.Printf("Testing 123", kelpie.AnyArgs[any]()).Panic("Oh no!"))
+//
+// AnyArgs can also be combined with other arguments to match the start or end of the parameter
+// list. For example the following matches any call where the first argument is "Dolly":
+//
+// printMock.Setup(print.Printf("Hello %s", "Dolly", kelpie.AnyArgs[any]()).Return("Hello Dolly"))
 func AnyArgs[T any]() mocking.Matcher[T] {
 	return mocking.AnyArgs[T]()
 }
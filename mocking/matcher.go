@@ -1,6 +1,9 @@
 package mocking
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+)
 
 // ArgumentMatcher can check whether an argument matches an expectation.
 type ArgumentMatcher interface {
@@ -36,7 +39,21 @@ type Matcher[T any] struct {
 
 // IsMatch returns true if other is a match to the expectation.
 func (i Matcher[T]) IsMatch(other any) bool {
-	return i.MatchFn(other.(T))
+	// A nil interface passed as an argument is recorded as a nil `any`, which can't be converted
+	// back to T using a type assertion. In that case we can just use T's zero value instead.
+	if other == nil {
+		var zero T
+		return i.MatchFn(zero)
+	}
+
+	// Arguments passed to an `...any` variadic parameter can be of any type, so it's possible for a
+	// matcher to be compared against an argument of a different type.
+	value, ok := other.(T)
+	if !ok {
+		return false
+	}
+
+	return i.MatchFn(value)
 }
 
 // MatcherType returns the matcher's type.
@@ -79,26 +96,176 @@ func (v *variadicMatcher) IsMatch(other any) bool {
 		}
 	}
 
-	if len(v.matchers) == 1 {
-		switch v.matchers[0].MatcherType() {
-		case MatcherTypeNone:
-			return len(args) == 0
-		case MatcherTypeAnyArgs:
-			return true
+	return matchArgs(v.matchers, args)
+}
+
+// matchArgs checks whether args match the list of matchers. AnyArgs matchers can appear anywhere
+// in the list and match zero or more arguments, allowing prefixes and suffixes to be matched. None
+// matchers don't match any arguments, so a list containing only None matches an empty argument list.
+func matchArgs(matchers []ArgumentMatcher, args []any) bool {
+	if len(matchers) == 0 {
+		return len(args) == 0
+	}
+
+	switch matchers[0].MatcherType() {
+	case MatcherTypeNone:
+		return matchArgs(matchers[1:], args)
+	case MatcherTypeAnyArgs:
+		for skip := 0; skip <= len(args); skip++ {
+			if matchArgs(matchers[1:], args[skip:]) {
+				return true
+			}
 		}
+
+		return false
 	}
 
-	if len(args) != len(v.matchers) {
+	if len(args) == 0 || !matchers[0].IsMatch(args[0]) {
 		return false
 	}
 
-	for index, matcher := range v.matchers {
-		if !matcher.IsMatch(args[index]) {
-			return false
+	return matchArgs(matchers[1:], args[1:])
+}
+
+// ValueMatcher creates a matcher for a single argument passed to a variadic parameter of type T.
+// The generated mocks accept any value for variadic parameters so that values and matchers can
+// be mixed, and use ValueMatcher to exactly match any values that aren't matchers. A nil arg is
+// treated as the nil value of T.
+//
+// Because the parameters accept any value, untyped constants take their default type, so for
+// example the 1 in Sum(1, 2.5) is an int even if Sum accepts ...int64. Numbers are converted to T
+// as long as no information is lost, along with other values whose type has the same underlying
+// kind as T, for example a string passed for a named string type.
+//
+// If arg is not a T and can't be converted to one, ValueMatcher panics since the mock has not been
+// setup correctly.
+func ValueMatcher[T any](arg any) ArgumentMatcher {
+	var value T
+	if arg != nil {
+		var ok bool
+		if value, ok = arg.(T); !ok {
+			if value, ok = convertValue[T](arg); !ok {
+				typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+				panic(fmt.Sprintf("Invalid argument of type %T. Expected a %s or a matcher.", arg, typeName))
+			}
+		}
+	} else if !isNillable(reflect.TypeOf((*T)(nil)).Elem()) {
+		typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+		panic(fmt.Sprintf("Invalid nil argument. Expected a %s or a matcher.", typeName))
+	}
+
+	return Matcher[T]{
+		MatchFn: func(input T) bool {
+			return reflect.DeepEqual(input, value)
+		},
+	}
+}
+
+// convertValue converts arg to T if it's a number that can be represented exactly as a T, or if it
+// has the same kind as T.
+func convertValue[T any](arg any) (T, bool) {
+	var value T
+	target := reflect.TypeOf((*T)(nil)).Elem()
+	source := reflect.ValueOf(arg)
+	if !source.Type().ConvertibleTo(target) {
+		return value, false
+	}
+
+	switch {
+	case source.Kind() == target.Kind() && isBasic(source.Kind()):
+		return source.Convert(target).Interface().(T), true
+	case isNumeric(source.Kind()) && isNumeric(target.Kind()):
+		// Converting back to the original type tells us whether the conversion lost any
+		// information, for example by truncating 1.5 to 1 or overflowing an int8.
+		converted := source.Convert(target)
+		if converted.Convert(source.Type()).Interface() != arg {
+			return value, false
 		}
+
+		return converted.Interface().(T), true
+	default:
+		return value, false
+	}
+}
+
+func isBasic(kind reflect.Kind) bool {
+	return kind == reflect.Bool || kind == reflect.String || isNumeric(kind)
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+type interfaceMatcher struct {
+	value any
+}
+
+// InterfaceMatcher creates a matcher for a parameter whose type is a non-empty interface. Because
+// Go doesn't allow non-empty interfaces to be used in type unions, the generated mocks accept any
+// value for these parameters, and use InterfaceMatcher to decide how to match them:
+//
+//   - If arg is a Matcher[T], it is used to match the parameter.
+//   - If arg is nil, the parameter only matches a nil interface. Typed nils (for example a nil
+//     pointer to a type that implements T) are not considered to be nil.
+//   - If arg implements T, the parameter matches if it is the same value as arg, or if it is deeply
+//     equal to arg.
+//
+// If arg is none of the above, InterfaceMatcher panics since the mock has not been setup correctly.
+func InterfaceMatcher[T any](arg any) ArgumentMatcher {
+	if matcher, ok := arg.(Matcher[T]); ok {
+		return matcher
+	}
+
+	if arg == nil {
+		return &interfaceMatcher{}
+	}
+
+	if _, ok := arg.(T); ok {
+		return &interfaceMatcher{value: arg}
+	}
+
+	typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+	panic(fmt.Sprintf("Invalid argument of type %T. Expected a %s or a mocking.Matcher[%s].", arg, typeName, typeName))
+}
+
+// MatcherType returns the type of the matcher.
+func (m *interfaceMatcher) MatcherType() MatcherType {
+	return MatcherTypeFn
+}
+
+// IsMatch returns true if other is a match to the expectation.
+func (m *interfaceMatcher) IsMatch(other any) bool {
+	if m.value == nil || other == nil {
+		return m.value == other
+	}
+
+	if reflect.TypeOf(m.value) != reflect.TypeOf(other) {
+		return false
+	}
+
+	// The dynamic type of the interface might not be comparable (for example if it's a slice), in
+	// which case comparing the values directly would panic.
+	if reflect.ValueOf(m.value).Comparable() && m.value == other {
+		return true
 	}
 
-	return true
+	return reflect.DeepEqual(m.value, other)
 }
 
 // None is used to indicate that no arguments should be passed to a variadic function.
@@ -109,7 +276,8 @@ func None[T any]() Matcher[T] {
 }
 
 // AnyArgs is used to indicate that any amount of arguments (including no arguments) should
-// be passed to a variadic function.
+// be passed to a variadic function. It can be combined with other matchers to match a prefix
+// or suffix of the arguments.
 func AnyArgs[T any]() Matcher[T] {
 	return Matcher[T]{
 		matcherType: MatcherTypeAnyArgs,
@@ -1,6 +1,9 @@
 package mocking_test
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/adamconnelly/kelpie"
@@ -12,6 +15,8 @@ type MatcherTests struct {
 	suite.Suite
 }
 
+type level string
+
 type options struct {
 	name *string
 }
@@ -80,6 +85,36 @@ func (t *MatcherTests) Test_VariadicMatcher_MatchesWhenParametersEmpty() {
 			inputs:   "testing",
 			isMatch:  false,
 		},
+		"Any args matcher matches prefix": {
+			matchers: []mocking.ArgumentMatcher{kelpie.ExactMatch("testing"), mocking.AnyArgs[any]()},
+			inputs:   []any{"testing", 1, 2, 3},
+			isMatch:  true,
+		},
+		"Any args matcher does not match incorrect prefix": {
+			matchers: []mocking.ArgumentMatcher{kelpie.ExactMatch("testing"), mocking.AnyArgs[any]()},
+			inputs:   []any{1, 2, 3},
+			isMatch:  false,
+		},
+		"Any args matcher matches suffix": {
+			matchers: []mocking.ArgumentMatcher{mocking.AnyArgs[any](), kelpie.ExactMatch(3)},
+			inputs:   []any{"testing", 1, 2, 3},
+			isMatch:  true,
+		},
+		"Any args matcher does not match incorrect suffix": {
+			matchers: []mocking.ArgumentMatcher{mocking.AnyArgs[any](), kelpie.ExactMatch(2)},
+			inputs:   []any{"testing", 1, 2, 3},
+			isMatch:  false,
+		},
+		"Any args matcher matches middle": {
+			matchers: []mocking.ArgumentMatcher{kelpie.ExactMatch("testing"), mocking.AnyArgs[any](), kelpie.ExactMatch(3)},
+			inputs:   []any{"testing", 1, 2, 3},
+			isMatch:  true,
+		},
+		"Any args matcher matches empty middle": {
+			matchers: []mocking.ArgumentMatcher{kelpie.ExactMatch("testing"), mocking.AnyArgs[any](), kelpie.ExactMatch(3)},
+			inputs:   []any{"testing", 3},
+			isMatch:  true,
+		},
 		"Arguments are slice of non-any type": {
 			matchers: []mocking.ArgumentMatcher{kelpie.Any[func(*options)]()},
 			inputs:   []func(*options){WithName("Bob")},
@@ -101,6 +136,201 @@ func (t *MatcherTests) Test_VariadicMatcher_MatchesWhenParametersEmpty() {
 	}
 }
 
+func (t *MatcherTests) Test_InterfaceMatcher() {
+	type testCase struct {
+		arg     any
+		input   any
+		isMatch bool
+	}
+
+	var nilBuffer *bytes.Buffer
+	buffer := bytes.NewBufferString("Hello World!")
+
+	testCases := map[string]testCase{
+		"Same value matches": {
+			arg:     buffer,
+			input:   buffer,
+			isMatch: true,
+		},
+		"Deeply equal value matches": {
+			arg:     bytes.NewBufferString("Hello World!"),
+			input:   buffer,
+			isMatch: true,
+		},
+		"Different value does not match": {
+			arg:     bytes.NewBufferString("Goodbye!"),
+			input:   buffer,
+			isMatch: false,
+		},
+		"Different types do not match": {
+			arg:     strings.NewReader("Hello World!"),
+			input:   buffer,
+			isMatch: false,
+		},
+		"Nil matches nil interface": {
+			arg:     nil,
+			input:   nil,
+			isMatch: true,
+		},
+		"Nil does not match typed nil": {
+			arg:     nil,
+			input:   nilBuffer,
+			isMatch: false,
+		},
+		"Typed nil matches typed nil": {
+			arg:     nilBuffer,
+			input:   nilBuffer,
+			isMatch: true,
+		},
+		"Typed nil does not match nil interface": {
+			arg:     nilBuffer,
+			input:   nil,
+			isMatch: false,
+		},
+		"Non-comparable values match when deeply equal": {
+			arg:     multiReader{strings.NewReader("a")},
+			input:   multiReader{strings.NewReader("a")},
+			isMatch: true,
+		},
+		"Matchers are used as-is": {
+			arg:     kelpie.Any[io.Reader](),
+			input:   buffer,
+			isMatch: true,
+		},
+		"Matchers can match nil interfaces": {
+			arg:     kelpie.ExactMatch[io.Reader](nil),
+			input:   nil,
+			isMatch: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func() {
+			// Arrange
+			matcher := mocking.InterfaceMatcher[io.Reader](tc.arg)
+
+			// Act
+			isMatch := matcher.IsMatch(tc.input)
+
+			// Assert
+			t.Equal(tc.isMatch, isMatch)
+		})
+	}
+}
+
+func (t *MatcherTests) Test_InterfaceMatcher_PanicsIfArgumentDoesNotImplementInterface() {
+	// Act
+	action := func() { mocking.InterfaceMatcher[io.Reader]("not a reader") }
+
+	// Assert
+	t.PanicsWithValue("Invalid argument of type string. Expected a io.Reader or a mocking.Matcher[io.Reader].", action)
+}
+
+func (t *MatcherTests) Test_ValueMatcher() {
+	type testCase struct {
+		matcher mocking.ArgumentMatcher
+		input   any
+		isMatch bool
+	}
+
+	var nilOptions *options
+
+	testCases := map[string]testCase{
+		"Equal values match": {
+			matcher: mocking.ValueMatcher[string]("testing"),
+			input:   "testing",
+			isMatch: true,
+		},
+		"Different values do not match": {
+			matcher: mocking.ValueMatcher[string]("testing"),
+			input:   "123",
+			isMatch: false,
+		},
+		"Deeply equal values match": {
+			matcher: mocking.ValueMatcher[[]int]([]int{1, 2, 3}),
+			input:   []int{1, 2, 3},
+			isMatch: true,
+		},
+		"Values match any": {
+			matcher: mocking.ValueMatcher[any](1),
+			input:   1,
+			isMatch: true,
+		},
+		"Ints are converted to int64": {
+			matcher: mocking.ValueMatcher[int64](1),
+			input:   int64(1),
+			isMatch: true,
+		},
+		"Ints are converted to float64": {
+			matcher: mocking.ValueMatcher[float64](2),
+			input:   2.0,
+			isMatch: true,
+		},
+		"Float64s are converted to float32": {
+			matcher: mocking.ValueMatcher[float32](2.5),
+			input:   float32(2.5),
+			isMatch: true,
+		},
+		"Values are converted to named types": {
+			matcher: mocking.ValueMatcher[level]("debug"),
+			input:   level("debug"),
+			isMatch: true,
+		},
+		"Nil matches nil pointer": {
+			matcher: mocking.ValueMatcher[*options](nil),
+			input:   nilOptions,
+			isMatch: true,
+		},
+		"Nil does not match non-nil pointer": {
+			matcher: mocking.ValueMatcher[*options](nil),
+			input:   &options{},
+			isMatch: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func() {
+			// Act
+			isMatch := tc.matcher.IsMatch(tc.input)
+
+			// Assert
+			t.Equal(tc.isMatch, isMatch)
+		})
+	}
+}
+
+func (t *MatcherTests) Test_ValueMatcher_PanicsIfArgumentIsIncorrectType() {
+	// Act
+	action := func() { mocking.ValueMatcher[string](123) }
+
+	// Assert
+	t.PanicsWithValue("Invalid argument of type int. Expected a string or a matcher.", action)
+}
+
+func (t *MatcherTests) Test_ValueMatcher_PanicsIfConversionLosesInformation() {
+	// Act
+	truncated := func() { mocking.ValueMatcher[int64](1.5) }
+	overflowed := func() { mocking.ValueMatcher[int8](300) }
+
+	// Assert
+	t.PanicsWithValue("Invalid argument of type float64. Expected a int64 or a matcher.", truncated)
+	t.PanicsWithValue("Invalid argument of type int. Expected a int8 or a matcher.", overflowed)
+}
+
+func (t *MatcherTests) Test_ValueMatcher_PanicsIfNilUsedForNonNillableType() {
+	// Act
+	action := func() { mocking.ValueMatcher[string](nil) }
+
+	// Assert
+	t.PanicsWithValue("Invalid nil argument. Expected a string or a matcher.", action)
+}
+
+type multiReader []io.Reader
+
+func (r multiReader) Read(p []byte) (int, error) {
+	return io.MultiReader(r...).Read(p)
+}
+
 func TestMatchers(t *testing.T) {
 	suite.Run(t, new(MatcherTests))
 }
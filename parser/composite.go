@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Combine creates a single mock implementing the combined method sets of the specified interfaces.
+// Methods that are included in more than one interface are only included once, as long as their
+// signatures are identical. An error is returned if the same method has different signatures in
+// different interfaces. The interfaces should be parsed using the same SharedImports, so that any
+// packages they reference with the same name are given different aliases.
+func Combine(name string, interfaces ...MockedInterface) (MockedInterface, error) {
+	combined := MockedInterface{
+		Name:        name,
+		FullName:    name,
+		PackageName: strings.ToLower(name),
+	}
+
+	methodSources := map[string]string{}
+	methods := map[string]MethodDefinition{}
+	imports := map[string]bool{}
+
+	for _, i := range interfaces {
+		if len(i.TypeParameters) > 0 {
+			return MockedInterface{}, fmt.Errorf("'%s' is generic, and so it can't be combined with other interfaces - try combining a specific instantiation instead", i.FullName)
+		}
+
+		if i.FunctionType != "" {
+			return MockedInterface{}, fmt.Errorf("'%s' is a function type, so it can't be combined with other interfaces", i.FullName)
+		}
+
+		// If any of the interfaces are only available to tests, so is the combined mock.
+		combined.IsTestOnly = combined.IsTestOnly || i.IsTestOnly
+
+		for _, method := range i.Methods {
+			if existing, ok := methods[method.Name]; ok {
+				if !haveSameSignature(existing, method) {
+					return MockedInterface{}, fmt.Errorf("method '%s' has different signatures in '%s' and '%s'", method.Name, methodSources[method.Name], i.FullName)
+				}
+
+				continue
+			}
+
+			methods[method.Name] = method
+			methodSources[method.Name] = i.FullName
+			combined.Methods = append(combined.Methods, method)
+		}
+
+		for _, imp := range i.Imports {
+			if !imports[imp] {
+				imports[imp] = true
+				combined.Imports = append(combined.Imports, imp)
+			}
+		}
+	}
+
+	slices.SortStableFunc(combined.Imports, compareImports)
+
+	return combined, nil
+}
+
+func haveSameSignature(a, b MethodDefinition) bool {
+	if len(a.Parameters) != len(b.Parameters) || len(a.Results) != len(b.Results) {
+		return false
+	}
+
+	for index := range a.Parameters {
+		if a.Parameters[index].Type != b.Parameters[index].Type || a.Parameters[index].IsVariadic != b.Parameters[index].IsVariadic {
+			return false
+		}
+	}
+
+	for index := range a.Results {
+		if a.Results[index].Type != b.Results[index].Type {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/parser"
+)
+
+type CompositeTests struct {
+	suite.Suite
+}
+
+func (t *CompositeTests) Test_Combine_IncludesMethodsFromAllInterfaces() {
+	// Arrange
+	reader := parser.MockedInterface{
+		FullName: "Reader",
+		Methods: []parser.MethodDefinition{
+			{Name: "Read", Parameters: []parser.ParameterDefinition{{Name: "p", Type: "[]byte"}}, Results: []parser.ResultDefinition{{Name: "n", Type: "int"}, {Name: "err", Type: "error"}}},
+		},
+	}
+	repository := parser.MockedInterface{
+		FullName: "UserRepository",
+		Methods: []parser.MethodDefinition{
+			{Name: "FindUser", Parameters: []parser.ParameterDefinition{{Name: "id", Type: "int"}}, Results: []parser.ResultDefinition{{Type: "*users.User"}}},
+		},
+		Imports: []string{`"github.com/adamconnelly/kelpie/examples/users"`},
+	}
+
+	// Act
+	combined, err := parser.Combine("UserReader", reader, repository)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("UserReader", combined.Name)
+	t.Equal("UserReader", combined.FullName)
+	t.Equal("userreader", combined.PackageName)
+	t.Equal([]parser.MethodDefinition{reader.Methods[0], repository.Methods[0]}, combined.Methods)
+	t.Equal([]string{`"github.com/adamconnelly/kelpie/examples/users"`}, combined.Imports)
+}
+
+func (t *CompositeTests) Test_Combine_IncludesDuplicateMethodsOnce() {
+	// Arrange
+	closer := parser.MockedInterface{
+		FullName: "Closer",
+		Methods:  []parser.MethodDefinition{{Name: "Close", Results: []parser.ResultDefinition{{Type: "error"}}}},
+	}
+	readCloser := parser.MockedInterface{
+		FullName: "ReadCloser",
+		Methods: []parser.MethodDefinition{
+			{Name: "Read", Parameters: []parser.ParameterDefinition{{Name: "p", Type: "[]byte"}}, Results: []parser.ResultDefinition{{Type: "int"}, {Type: "error"}}},
+			{Name: "Close", Results: []parser.ResultDefinition{{Name: "err", Type: "error"}}},
+		},
+	}
+
+	// Act
+	combined, err := parser.Combine("ReadCloser", closer, readCloser)
+
+	// Assert
+	t.NoError(err)
+	t.Len(combined.Methods, 2)
+	t.Equal("Close", combined.Methods[0].Name)
+	t.Equal("Read", combined.Methods[1].Name)
+}
+
+func (t *CompositeTests) Test_Combine_ReturnsErrorIfSignaturesConflict() {
+	// Arrange
+	closer := parser.MockedInterface{
+		FullName: "Closer",
+		Methods:  []parser.MethodDefinition{{Name: "Close", Results: []parser.ResultDefinition{{Type: "error"}}}},
+	}
+	shutdowner := parser.MockedInterface{
+		FullName: "Shutdowner",
+		Methods:  []parser.MethodDefinition{{Name: "Close", Parameters: []parser.ParameterDefinition{{Name: "force", Type: "bool"}}}},
+	}
+
+	// Act
+	_, err := parser.Combine("Service", closer, shutdowner)
+
+	// Assert
+	t.EqualError(err, "method 'Close' has different signatures in 'Closer' and 'Shutdowner'")
+}
+
+func (t *CompositeTests) Test_Combine_ReturnsErrorIfVariadicParametersConflict() {
+	// Arrange
+	printer := parser.MockedInterface{
+		FullName: "Printer",
+		Methods:  []parser.MethodDefinition{{Name: "Print", Parameters: []parser.ParameterDefinition{{Name: "args", Type: "string", IsVariadic: true}}}},
+	}
+	writer := parser.MockedInterface{
+		FullName: "Writer",
+		Methods:  []parser.MethodDefinition{{Name: "Print", Parameters: []parser.ParameterDefinition{{Name: "value", Type: "string"}}}},
+	}
+
+	// Act
+	_, err := parser.Combine("PrintWriter", printer, writer)
+
+	// Assert
+	t.EqualError(err, "method 'Print' has different signatures in 'Printer' and 'Writer'")
+}
+
+func (t *CompositeTests) Test_Combine_ReturnsErrorForGenericInterfaces() {
+	// Arrange
+	repository := parser.MockedInterface{
+		FullName:       "Repository",
+		TypeParameters: []parser.TypeParameterDefinition{{Name: "T", Constraint: "any"}},
+	}
+
+	// Act
+	_, err := parser.Combine("Store", repository)
+
+	// Assert
+	t.ErrorContains(err, "'Repository' is generic")
+}
+
+func (t *CompositeTests) Test_Combine_ReturnsErrorForFunctionTypes() {
+	// Arrange
+	authorizer := parser.MockedInterface{
+		FullName:     "Authorizer",
+		FunctionType: "auth.Authorizer",
+	}
+
+	// Act
+	_, err := parser.Combine("Security", authorizer)
+
+	// Assert
+	t.ErrorContains(err, "'Authorizer' is a function type")
+}
+
+func TestComposite(t *testing.T) {
+	suite.Run(t, new(CompositeTests))
+}
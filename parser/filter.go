@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/adamconnelly/kelpie/slices"
+)
+
+// TypeFilter can optionally be implemented by an InterfaceFilter to decide whether struct and function
+// types should be mocked. Filters that don't implement it use Include for all types.
+type TypeFilter interface {
+	// IncludeType indicates that the specified struct or function type should be mocked.
+	IncludeType(name string) bool
+}
+
+// includeType returns true if the specified struct or function type should be mocked.
+func includeType(filter InterfaceFilter, name string) bool {
+	if typeFilter, ok := filter.(TypeFilter); ok {
+		return typeFilter.IncludeType(name)
+	}
+
+	return filter.Include(name)
+}
+
+// GlobInterfaceFilter is an InterfaceFilter that includes any interfaces matching a set of glob
+// patterns, for example `*Repository`. A `*` matches any sequence of characters and a `?` matches
+// a single character, but neither match the `.` used to separate the parts of a nested interface's
+// name. This means that `*` matches all the interfaces declared directly in a package, while
+// `UserService.*` matches the interfaces nested inside UserService.
+//
+// Patterns only match interfaces. Struct and function types need to be included by name.
+type GlobInterfaceFilter struct {
+	expressions []*regexp.Regexp
+}
+
+// NewGlobInterfaceFilter creates a filter that includes any interfaces matching the patterns.
+func NewGlobInterfaceFilter(patterns ...string) *GlobInterfaceFilter {
+	return &GlobInterfaceFilter{
+		expressions: slices.Map(patterns, func(pattern string) *regexp.Regexp {
+			var expression strings.Builder
+			expression.WriteString("^")
+			for _, r := range pattern {
+				switch r {
+				case '*':
+					expression.WriteString(`[^.]*`)
+				case '?':
+					expression.WriteString(`[^.]`)
+				default:
+					expression.WriteString(regexp.QuoteMeta(string(r)))
+				}
+			}
+			expression.WriteString("$")
+
+			return regexp.MustCompile(expression.String())
+		}),
+	}
+}
+
+// Include returns true if the interface matches any of the patterns.
+func (f *GlobInterfaceFilter) Include(name string) bool {
+	return matchesAny(f.expressions, name)
+}
+
+// IncludeType returns false, since patterns only match interfaces.
+func (f *GlobInterfaceFilter) IncludeType(name string) bool {
+	return false
+}
+
+// RegexInterfaceFilter is an InterfaceFilter that includes any interfaces whose names match a set of
+// regular expressions. The expressions must match the entire name, including the path to any nested
+// interfaces, for example `UserService\..*`.
+//
+// Expressions only match interfaces. Struct and function types need to be included by name.
+type RegexInterfaceFilter struct {
+	expressions []*regexp.Regexp
+}
+
+// NewRegexInterfaceFilter creates a filter that includes any interfaces matching the regular expressions.
+func NewRegexInterfaceFilter(expressions ...string) (*RegexInterfaceFilter, error) {
+	filter := RegexInterfaceFilter{}
+	for _, expression := range expressions {
+		compiled, err := regexp.Compile("^(?:" + expression + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse regular expression '%s'", expression)
+		}
+
+		filter.expressions = append(filter.expressions, compiled)
+	}
+
+	return &filter, nil
+}
+
+// Include returns true if the interface matches any of the regular expressions.
+func (f *RegexInterfaceFilter) Include(name string) bool {
+	return matchesAny(f.expressions, name)
+}
+
+// IncludeType returns false, since regular expressions only match interfaces.
+func (f *RegexInterfaceFilter) IncludeType(name string) bool {
+	return false
+}
+
+// AnyInterfaceFilter is an InterfaceFilter that includes an interface if any of its filters include it.
+type AnyInterfaceFilter struct {
+	Filters []InterfaceFilter
+}
+
+// Include returns true if any of the filters include the interface.
+func (f *AnyInterfaceFilter) Include(name string) bool {
+	return slices.Contains(f.Filters, func(filter InterfaceFilter) bool { return filter.Include(name) })
+}
+
+// IncludeType returns true if any of the filters include the type.
+func (f *AnyInterfaceFilter) IncludeType(name string) bool {
+	return slices.Contains(f.Filters, func(filter InterfaceFilter) bool { return includeType(filter, name) })
+}
+
+// Instantiations returns the instantiations requested by any of the filters.
+func (f *AnyInterfaceFilter) Instantiations(name string) []string {
+	var instantiations []string
+	for _, filter := range f.Filters {
+		if instantiationFilter, ok := filter.(InstantiationFilter); ok {
+			for _, instantiation := range instantiationFilter.Instantiations(name) {
+				if !slices.Contains(instantiations, func(i string) bool { return i == instantiation }) {
+					instantiations = append(instantiations, instantiation)
+				}
+			}
+		}
+	}
+
+	return instantiations
+}
+
+// ExcludingInterfaceFilter is an InterfaceFilter that includes anything included by Filter, except
+// for anything included by Exclude. For example it can be used to mock all the interfaces in a
+// package except for a few specific ones.
+type ExcludingInterfaceFilter struct {
+	Filter  InterfaceFilter
+	Exclude InterfaceFilter
+}
+
+// Include returns true if the interface is included, and hasn't been excluded.
+func (f *ExcludingInterfaceFilter) Include(name string) bool {
+	return f.Filter.Include(name) && !f.Exclude.Include(name)
+}
+
+// IncludeType returns true if the type is included, and hasn't been excluded.
+func (f *ExcludingInterfaceFilter) IncludeType(name string) bool {
+	return includeType(f.Filter, name) && !f.Exclude.Include(name)
+}
+
+// Instantiations returns the instantiations requested by Filter that haven't been excluded.
+func (f *ExcludingInterfaceFilter) Instantiations(name string) []string {
+	instantiationFilter, ok := f.Filter.(InstantiationFilter)
+	if !ok {
+		return nil
+	}
+
+	return slices.All(instantiationFilter.Instantiations(name), func(instantiation string) bool {
+		return !f.Exclude.Include(instantiation)
+	})
+}
+
+func matchesAny(expressions []*regexp.Regexp, name string) bool {
+	return slices.Contains(expressions, func(expression *regexp.Regexp) bool { return expression.MatchString(name) })
+}
@@ -0,0 +1,124 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adamconnelly/kelpie/parser"
+)
+
+type FilterTests struct {
+	suite.Suite
+}
+
+func (t *FilterTests) Test_GlobInterfaceFilter_MatchesPatterns() {
+	// Arrange
+	filter := parser.NewGlobInterfaceFilter("*Repository", "Cache?")
+
+	// Act
+	// Assert
+	t.True(filter.Include("UserRepository"))
+	t.True(filter.Include("Repository"))
+	t.True(filter.Include("Cache1"))
+	t.False(filter.Include("UserRepositoryFactory"))
+	t.False(filter.Include("Cache"))
+	t.False(filter.Include("Cache12"))
+}
+
+func (t *FilterTests) Test_GlobInterfaceFilter_WildcardsDoNotMatchNestedInterfaces() {
+	// Arrange
+	filter := parser.NewGlobInterfaceFilter("*")
+	nestedFilter := parser.NewGlobInterfaceFilter("ConfigService.*")
+
+	// Act
+	// Assert
+	t.True(filter.Include("ConfigService"))
+	t.False(filter.Include("ConfigService.Encrypter"))
+	t.True(nestedFilter.Include("ConfigService.Encrypter"))
+	t.False(nestedFilter.Include("ConfigService.Storage.Backend"))
+}
+
+func (t *FilterTests) Test_GlobInterfaceFilter_TreatsOtherCharactersLiterally() {
+	// Arrange
+	filter := parser.NewGlobInterfaceFilter("Cache[string, int]")
+
+	// Act
+	// Assert
+	t.True(filter.Include("Cache[string, int]"))
+	t.False(filter.Include("Caches"))
+}
+
+func (t *FilterTests) Test_GlobInterfaceFilter_DoesNotIncludeTypes() {
+	// Arrange
+	filter := parser.NewGlobInterfaceFilter("*")
+
+	// Act
+	// Assert
+	t.False(filter.IncludeType("Client"))
+}
+
+func (t *FilterTests) Test_RegexInterfaceFilter_MatchesEntireName() {
+	// Arrange
+	filter, err := parser.NewRegexInterfaceFilter("(User|Account)Service")
+
+	// Act
+	// Assert
+	t.NoError(err)
+	t.True(filter.Include("UserService"))
+	t.True(filter.Include("AccountService"))
+	t.False(filter.Include("UserServiceFactory"))
+	t.False(filter.Include("MyUserService"))
+	t.False(filter.IncludeType("UserService"))
+}
+
+func (t *FilterTests) Test_RegexInterfaceFilter_ReturnsErrorForInvalidExpression() {
+	// Arrange
+	// Act
+	_, err := parser.NewRegexInterfaceFilter("User(")
+
+	// Assert
+	t.ErrorContains(err, "could not parse regular expression 'User('")
+}
+
+func (t *FilterTests) Test_AnyInterfaceFilter_IncludesInterfacesMatchingAnyFilter() {
+	// Arrange
+	filter := parser.AnyInterfaceFilter{
+		Filters: []parser.InterfaceFilter{
+			parser.NewGlobInterfaceFilter("*Repository"),
+			&parser.IncludingInterfaceFilter{InterfacesToInclude: []string{"Client", "Cache[string, int]"}},
+		},
+	}
+
+	// Act
+	// Assert
+	t.True(filter.Include("UserRepository"))
+	t.True(filter.Include("Client"))
+	t.False(filter.Include("UserService"))
+	t.True(filter.IncludeType("Client"))
+	t.False(filter.IncludeType("UserRepository"))
+	t.Equal([]string{"Cache[string, int]"}, filter.Instantiations("Cache"))
+}
+
+func (t *FilterTests) Test_ExcludingInterfaceFilter_ExcludesMatchingInterfaces() {
+	// Arrange
+	filter := parser.ExcludingInterfaceFilter{
+		Filter: &parser.AnyInterfaceFilter{
+			Filters: []parser.InterfaceFilter{
+				parser.NewGlobInterfaceFilter("*"),
+				&parser.IncludingInterfaceFilter{InterfacesToInclude: []string{"Cache[string, int]", "Cache[int, int]"}},
+			},
+		},
+		Exclude: parser.NewGlobInterfaceFilter("internal*", "Cache[int, int]"),
+	}
+
+	// Act
+	// Assert
+	t.True(filter.Include("UserRepository"))
+	t.False(filter.Include("internalOnly"))
+	t.Equal([]string{"Cache[string, int]"}, filter.Instantiations("Cache"))
+}
+
+func TestFilters(t *testing.T) {
+	suite.Run(t, new(FilterTests))
+}
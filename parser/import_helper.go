@@ -1,12 +1,14 @@
 package parser
 
 import (
-	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
-	"path/filepath"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"golang.org/x/tools/go/packages"
 
@@ -14,120 +16,203 @@ import (
 )
 
 type importHelper struct {
-	typesInfo             *types.Info
-	packageNamesToImports map[string]string
-	requiredImports       []string
+	packageName           string
+	packagePath           string
+	inPackage             bool
+	packagePathsToImports map[string]importSpec
+	imports               *ImportSet
 }
 
-func newImportHelper(typesInfo *types.Info, importSpecs []*ast.ImportSpec, p *packages.Package) *importHelper {
-	if typesInfo == nil {
-		panic("typesInfo cannot be nil")
-	}
+// ImportSet contains the packages imported by a mock, and the names used to reference them. A set can
+// be shared between several calls to Parse using the SharedImports option, which allows interfaces
+// from different packages to be combined into a single mock without their imports clashing.
+type ImportSet struct {
+	packagePathsToNames map[string]string
+	requiredImports     []string
+}
+
+// NewImportSet creates an empty ImportSet.
+func NewImportSet() *ImportSet {
+	return &ImportSet{packagePathsToNames: map[string]string{}}
+}
+
+type importSpec struct {
+	name string
+	path string
+}
 
-	packageNamesToImports := make(map[string]string, len(importSpecs))
+func newImportHelper(importSpecs []*ast.ImportSpec, p *packages.Package, opts parseOptions) *importHelper {
+	packagePathsToImports := make(map[string]importSpec, len(importSpecs))
 	for _, i := range importSpecs {
-		if i.Name != nil && i.Path.Value != "" {
-			packageName := i.Name.Name
-			if packageName == "." {
-				packageName = strings.Trim(i.Path.Value, `"`)
-			}
-
-			packageNamesToImports[packageName] = i.Name.Name + ` ` + i.Path.Value
-		} else if i.Name != nil {
-			packageNamesToImports[i.Name.Name] = `"` + i.Name.Name + `"`
-		} else if i.Path.Value != "" {
-			packageName := filepath.Base(strings.Trim(i.Path.Value, `"`))
-			packageNamesToImports[packageName] = i.Path.Value
+		spec := importSpec{path: strings.Trim(i.Path.Value, `"`)}
+		if i.Name != nil {
+			spec.name = i.Name.Name
 		}
+
+		packagePathsToImports[spec.path] = spec
 	}
 
-	// Add a special mapping for the package being parsed to resolve local types
-	packageNamesToImports[p.PkgPath] = `"` + p.PkgPath + `"`
+	imports := opts.imports
+	if imports == nil {
+		imports = NewImportSet()
+	}
 
 	return &importHelper{
-		typesInfo:             typesInfo,
-		packageNamesToImports: packageNamesToImports,
+		packageName:           p.Name,
+		packagePath:           p.PkgPath,
+		inPackage:             opts.inPackage,
+		packagePathsToImports: packagePathsToImports,
+		imports:               imports,
 	}
 }
 
-func (i *importHelper) AddImportsRequiredForType(e ast.Expr) {
-	for _, identifier := range i.getPackageIdentifiers(e) {
-		packageName := identifier.Name
+// mockingPackagePath is the path of Kelpie's mocking package, which is always imported by the
+// generated mocks.
+const mockingPackagePath = "github.com/adamconnelly/kelpie/mocking"
+
+// reservedPackageNames contains the names of the packages imported by the generated mocks. Any other
+// packages with the same names need to be imported using an alias to avoid clashes.
+var reservedPackageNames = []string{"kelpie", "mocking"}
+
+// majorVersionPattern matches the major version suffix of a module path, for example the v2 in
+// github.com/org/lib/v2.
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// Qualifier is a types.Qualifier that returns the name that should be used to reference the
+// specified package from the generated mock, and records the import needed to do so. Packages are
+// identified by their path, and any packages whose names clash with another package required by
+// the mock are imported using an alias.
+func (i *importHelper) Qualifier(pkg *types.Package) string {
+	if pkg.Path() == i.packagePath && i.inPackage {
+		// When the mock is generated in the same package, types from the package can be
+		// referenced directly.
+		return ""
+	}
 
-		// First let's check if the package name is in the import map. This handles standard
-		// expressions like `kelpie.Parser`.
-		if imp, ok := i.packageNamesToImports[packageName]; ok {
-			i.addImport(imp)
-			continue
-		}
+	if pkg.Path() == mockingPackagePath {
+		return "mocking"
+	}
 
-		// If we couldn't find it, the package might be implicit because of a dot import. We
-		// can use typesInfo.Uses to find the package for the identifier and look it up that way.
-		if use, ok := i.typesInfo.Uses[identifier]; ok {
-			pkg := use.Pkg()
-			if pkg == nil {
-				// If we found a usage but there's no package, it's a built-in type so no import
-				// is required. Just ignore and continue.
-				continue
-			}
-
-			if imp, ok := i.packageNamesToImports[pkg.Path()]; ok {
-				i.addImport(imp)
-				continue
-			}
-		}
+	name, ok := i.imports.packagePathsToNames[pkg.Path()]
+	if !ok {
+		name = i.addPackage(pkg)
+	}
 
-		panic(fmt.Sprintf("Could not find import statement for identifier %v. This is a bug in Kelpie!", identifier))
+	if name == "." {
+		return ""
 	}
+
+	return name
 }
 
-func (i *importHelper) RequiredImports() []string {
-	// Make sure the imports are sorted so that the code generation is stable.
-	slices.SortStableFunc(i.requiredImports, strings.Compare)
+// addPackage chooses the name used to reference the package from the mock, and adds its import.
+func (i *importHelper) addPackage(pkg *types.Package) string {
+	// If the file containing the interface imports the package, we use the same import name so
+	// that the generated mock matches the source as closely as possible, including dot imports.
+	name, alias := pkg.Name(), ""
+	if spec, ok := i.packagePathsToImports[pkg.Path()]; ok && pkg.Path() != i.packagePath && spec.name != "" && spec.name != "_" {
+		name, alias = spec.name, spec.name
+	}
+
+	if name != "." && !i.isNameAvailable(name) {
+		name = i.uniquePackageName(pkg.Path(), name)
+		alias = name
+	}
 
-	return i.requiredImports
+	i.imports.packagePathsToNames[pkg.Path()] = name
+
+	if alias == "" {
+		i.addImport(`"` + pkg.Path() + `"`)
+	} else {
+		i.addImport(alias + ` "` + pkg.Path() + `"`)
+	}
+
+	return name
 }
 
-func (i *importHelper) getPackageIdentifiers(e ast.Expr) []*ast.Ident {
-	identifiers := []*ast.Ident{}
-
-	switch n := e.(type) {
-	case *ast.Ident:
-		identifiers = append(identifiers, n)
-	case *ast.ArrayType:
-		identifiers = append(identifiers, i.getPackageIdentifiers(n.Elt)...)
-	case *ast.StarExpr:
-		identifiers = append(identifiers, i.getPackageIdentifiers(n.X)...)
-	case *ast.SelectorExpr:
-		identifiers = append(identifiers, i.getPackageIdentifiers(n.X)...)
-	case *ast.MapType:
-		identifiers = append(identifiers, i.getPackageIdentifiers(n.Key)...)
-		identifiers = append(identifiers, i.getPackageIdentifiers(n.Value)...)
-	case *ast.Ellipsis:
-		identifiers = append(identifiers, i.getPackageIdentifiers(n.Elt)...)
-	case *ast.FuncType:
-		for _, param := range n.Params.List {
-			identifiers = append(identifiers, i.getPackageIdentifiers(param.Type)...)
+// uniquePackageName returns a name for the package that doesn't clash with any other packages. The
+// name is made unique by prefixing it with the parent directories from the package's path, so that
+// for example k8s.io/api/core/v1 becomes corev1. If that doesn't work, a number is added to the name.
+func (i *importHelper) uniquePackageName(path, name string) string {
+	elements := strings.Split(path, "/")
+
+	// Any major version suffix has already been accounted for if the package name matches it, and
+	// otherwise it's not part of the package's directory.
+	if len(elements) > 1 && IsMajorVersion(elements[len(elements)-1]) && elements[len(elements)-1] != name {
+		elements = elements[:len(elements)-1]
+	}
+
+	prefix := ""
+	for index := len(elements) - 2; index >= 0; index-- {
+		prefix = identifierCharacters(elements[index]) + prefix
+		if candidate := prefix + name; prefix != "" && token.IsIdentifier(candidate) && i.isNameAvailable(candidate) {
+			return candidate
 		}
+	}
 
-		if n.Results != nil {
-			for _, result := range n.Results.List {
-				identifiers = append(identifiers, i.getPackageIdentifiers(result.Type)...)
-			}
+	for suffix := 1; ; suffix++ {
+		if candidate := name + strconv.Itoa(suffix); i.isNameAvailable(candidate) {
+			return candidate
 		}
-	case *ast.InterfaceType:
-		// No need to check for any types for empty interfaces. Might need to adjust this in future
-		// if it turns out it's valid to have non-empty interfaces as parameters/results.
-		break
-	default:
-		panic(fmt.Sprintf("Could not get package identifier from ast expression: %v. This is a bug in Kelpie!", e))
 	}
+}
+
+// isNameAvailable returns true if the name isn't reserved, and hasn't been used by another package.
+func (i *importHelper) isNameAvailable(name string) bool {
+	if slices.Contains(reservedPackageNames, name) {
+		return false
+	}
+
+	for _, usedName := range i.imports.packagePathsToNames {
+		if usedName == name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsMajorVersion returns true if the element of a package path is a major version suffix, for
+// example the v2 in github.com/org/lib/v2.
+func IsMajorVersion(element string) bool {
+	return majorVersionPattern.MatchString(element)
+}
+
+// identifierCharacters returns the lowercased letters and digits from a path element, for example
+// turning "go-yaml" into "goyaml".
+func identifierCharacters(element string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
 
-	return identifiers
+		return -1
+	}, element)
+}
+
+func (i *importHelper) RequiredImports() []string {
+	// Make sure the imports are sorted by path so that the code generation is stable, and matches
+	// the order used by gofmt.
+	slices.SortStableFunc(i.imports.requiredImports, compareImports)
+
+	// The set could be shared with other interfaces, so we return a copy to avoid their imports
+	// being added to this interface's slice.
+	return slices.Clone(i.imports.requiredImports)
 }
 
 func (i *importHelper) addImport(imp string) {
-	if !kslices.Contains(i.requiredImports, func(i string) bool { return imp == i }) {
-		i.requiredImports = append(i.requiredImports, imp)
+	if !kslices.Contains(i.imports.requiredImports, func(i string) bool { return imp == i }) {
+		i.imports.requiredImports = append(i.imports.requiredImports, imp)
 	}
 }
+
+// compareImports orders imports by their paths, ignoring any aliases, which is the same order
+// used by gofmt.
+func compareImports(a, b string) int {
+	return strings.Compare(importPath(a), importPath(b))
+}
+
+// importPath returns the path from an import, ignoring any alias.
+func importPath(imp string) string {
+	return imp[strings.Index(imp, `"`):]
+}
@@ -5,6 +5,9 @@ package parser
 import (
 	"fmt"
 	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
 	"go/types"
 	"path/filepath"
 	"strconv"
@@ -13,7 +16,6 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 
-	"github.com/adamconnelly/kelpie/maps"
 	"github.com/adamconnelly/kelpie/slices"
 )
 
@@ -22,6 +24,12 @@ type ParsedPackage struct {
 	// PackageDirectory is the directory containing the package source files.
 	PackageDirectory string
 
+	// PackageName is the name of the package, as used in its package clause.
+	PackageName string
+
+	// PackagePath is the import path of the package.
+	PackagePath string
+
 	// Mocks are the mocks that were parsed from the package.
 	Mocks []MockedInterface
 }
@@ -38,11 +46,92 @@ type MockedInterface struct {
 	// PackageName contains the name of the package that the interface belongs to.
 	PackageName string
 
+	// TypeParameters contains the type parameters of a generic interface. This is empty for
+	// non-generic interfaces.
+	TypeParameters []TypeParameterDefinition
+
 	// Methods contains the list of methods in the interface.
 	Methods []MethodDefinition
 
 	// Imports contains the list of imports required by the mocked interface.
 	Imports []string
+
+	// StructType contains a pointer to the struct type being mocked when mocking the method set of a
+	// struct rather than an interface, for example `*billing.Client`. An interface declaration matching
+	// the struct's exported methods is generated alongside the mock. This is empty for interfaces.
+	StructType string
+
+	// IsTestOnly indicates that the interface is declared in a _test.go file, meaning that it can
+	// only be referenced by mocks generated in test files in the same package.
+	IsTestOnly bool
+
+	// Annotation contains the //kelpie:mock annotation from the type's doc comment, or nil if the
+	// type isn't annotated.
+	Annotation *MockAnnotation
+
+	// FunctionType contains the type being mocked when mocking a function type rather than an
+	// interface, for example `auth.Authorizer`. Function type mocks contain a single method called
+	// `Call`, and their instance is a value of the function type. This is empty for interfaces.
+	FunctionType string
+}
+
+// MockAnnotation is a `//kelpie:mock` comment used to mark a type that should be mocked, optionally
+// followed by a list of options. For example:
+//
+//	//kelpie:mock package=emailmock inPackage
+//	type EmailSender interface {
+//		// ...
+//	}
+type MockAnnotation struct {
+	// Options contains the options specified after the annotation, for example `package=emailmock`.
+	// Options specified without a value, like `inPackage`, are set to "true".
+	Options map[string]string
+}
+
+// mockAnnotationPrefix is the prefix of the comment used to annotate types that should be mocked.
+const mockAnnotationPrefix = "//kelpie:mock"
+
+// parseAnnotation finds the //kelpie:mock annotation in the specified doc comment. Returns nil
+// if the comment doesn't contain an annotation.
+func parseAnnotation(doc *ast.CommentGroup) *MockAnnotation {
+	if doc == nil {
+		return nil
+	}
+
+	// We use the raw comments rather than doc.Text(), since Text() strips out directive comments.
+	for _, comment := range doc.List {
+		if comment.Text != mockAnnotationPrefix && !strings.HasPrefix(comment.Text, mockAnnotationPrefix+" ") {
+			continue
+		}
+
+		annotation := MockAnnotation{Options: map[string]string{}}
+		for _, option := range strings.Fields(strings.TrimPrefix(comment.Text, mockAnnotationPrefix)) {
+			key, value, found := strings.Cut(option, "=")
+			if !found {
+				value = "true"
+			}
+
+			annotation.Options[key] = value
+		}
+
+		return &annotation
+	}
+
+	return nil
+}
+
+// AnyMethodsHaveExactMatchParameters returns true if at least one method in the interface has a
+// parameter that is matched using an exact match rather than an interface or variadic matcher.
+func (i MockedInterface) AnyMethodsHaveExactMatchParameters() bool {
+	for _, method := range i.Methods {
+		for _, param := range method.Parameters {
+			if !param.IsNonEmptyInterface && !param.IsVariadic {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // AnyMethodsHaveParameters returns true if at least one method in the interface has at least
@@ -57,6 +146,15 @@ func (i MockedInterface) AnyMethodsHaveParameters() bool {
 	return false
 }
 
+// TypeParameterDefinition contains information about a type parameter of a generic interface.
+type TypeParameterDefinition struct {
+	// Name is the name of the type parameter, for example `T`.
+	Name string
+
+	// Constraint is the type constraint of the type parameter, for example `any` or `comparable`.
+	Constraint string
+}
+
 // MethodDefinition defines a method in an interface.
 type MethodDefinition struct {
 	// Name is the name of the method.
@@ -72,6 +170,23 @@ type MethodDefinition struct {
 	Comment string
 }
 
+// ChannelElementType returns the element type of the channel returned by the method, as long as
+// exactly one of the method's results is a channel that values can be received from. Otherwise an
+// empty string is returned.
+func (m MethodDefinition) ChannelElementType() string {
+	channelResults := slices.All(m.Results, func(r ResultDefinition) bool { return r.ChannelElementType != "" })
+	if len(channelResults) != 1 {
+		return ""
+	}
+
+	return channelResults[0].ChannelElementType
+}
+
+// FixedParameters returns the method's parameters, excluding any variadic parameter.
+func (m MethodDefinition) FixedParameters() []ParameterDefinition {
+	return slices.All(m.Parameters, func(p ParameterDefinition) bool { return !p.IsVariadic })
+}
+
 // ParameterDefinition contains information about a method parameter.
 type ParameterDefinition struct {
 	// Name is the name of the parameter.
@@ -85,6 +200,9 @@ type ParameterDefinition struct {
 
 	// IsNonEmptyInterface indicates that the parameter type is an interface with at least one method.
 	IsNonEmptyInterface bool
+
+	// IsTypeParameter indicates that the parameter type is one of the interface's type parameters.
+	IsTypeParameter bool
 }
 
 // ResultDefinition contains information about a method result.
@@ -94,6 +212,10 @@ type ResultDefinition struct {
 
 	// Type is the type of the result.
 	Type string
+
+	// ChannelElementType contains the element type of the channel if the result is a channel that
+	// values can be received from. It is empty for any other type of result.
+	ChannelElementType string
 }
 
 // InterfaceFilter is used to decide which interfaces mocks should be generated for.
@@ -116,260 +238,868 @@ func (f *IncludingInterfaceFilter) Include(name string) bool {
 	})
 }
 
-// Parse parses the source contained in the reader.
-func Parse(packageName string, directory string, filter InterfaceFilter) (*ParsedPackage, error) {
+// IncludeType returns true if the specified struct or function type is included in the allow-list.
+func (f *IncludingInterfaceFilter) IncludeType(name string) bool {
+	return f.Include(name)
+}
+
+// InstantiationFilter can optionally be implemented by an InterfaceFilter to request mocks of
+// specific instantiations of generic interfaces.
+type InstantiationFilter interface {
+	// Instantiations returns the instantiations of the specified generic interface that mocks should
+	// be generated for, for example `Cache[string, users.User]`. The type arguments are resolved
+	// using the imports of the file containing the interface.
+	Instantiations(name string) []string
+}
+
+// Instantiations returns any instantiations of the specified generic interface included in the
+// allow-list.
+func (f *IncludingInterfaceFilter) Instantiations(name string) []string {
+	return slices.All(f.InterfacesToInclude, func(n string) bool {
+		return strings.HasPrefix(n, name+"[")
+	})
+}
+
+// ParseOption customizes how a package is parsed.
+type ParseOption func(opts *parseOptions)
+
+type parseOptions struct {
+	inPackage bool
+	annotated bool
+	variant   PackageVariant
+	imports   *ImportSet
+}
+
+// PackageVariant defines which variant of a package to parse. When a package is loaded along with its
+// tests, Go produces several variants of the package: the package on its own, the package including its
+// in-package test files, and the external test package (for example `users_test`).
+type PackageVariant string
+
+const (
+	// PackageVariantTest is the package including any _test.go files in the same package. This is the
+	// default, and allows interfaces declared in test files to be mocked.
+	PackageVariantTest PackageVariant = "test"
+
+	// PackageVariantPackage is the package without any of its test files.
+	PackageVariantPackage PackageVariant = "package"
+
+	// PackageVariantExternalTest is the package's external test package, for example `users_test`.
+	PackageVariantExternalTest PackageVariant = "xtest"
+)
+
+// Variant selects the variant of the package to parse. Defaults to PackageVariantTest.
+func Variant(variant PackageVariant) ParseOption {
+	return func(opts *parseOptions) {
+		opts.variant = variant
+	}
+}
+
+// Annotated includes any types annotated with a //kelpie:mock comment, as well as any types included
+// by the filter.
+func Annotated() ParseOption {
+	return func(opts *parseOptions) {
+		opts.annotated = true
+	}
+}
+
+// SharedImports resolves the imports of the parsed mocks using the specified set, rather than giving
+// each mock its own imports. This is used when combining interfaces from several packages into a
+// single mock, so that packages with the same name are given different aliases.
+func SharedImports(imports *ImportSet) ParseOption {
+	return func(opts *parseOptions) {
+		opts.imports = imports
+	}
+}
+
+// InPackage indicates that the mocks will be generated inside the package being parsed rather than
+// in a separate mock package. This means that types from the package don't need to be qualified or
+// imported, and allows unexported interfaces to be mocked.
+func InPackage() ParseOption {
+	return func(opts *parseOptions) {
+		opts.inPackage = true
+	}
+}
+
+// LoadedPackages contains the type information for a set of packages loaded using Load.
+type LoadedPackages struct {
+	packages []*packages.Package
+
+	// importPaths contains the import paths of any packages specified using relative or absolute
+	// paths, for example ".", keyed by the path.
+	importPaths map[string]string
+}
+
+// Load loads the type information for the specified packages, resolving them relative to directory.
+// All the packages are loaded together, so any dependencies they share are only type-checked once.
+// The packages can then be parsed using LoadedPackages.Parse.
+func Load(directory string, packageNames ...string) (*LoadedPackages, error) {
+	// The loaded packages are identified by their import paths, so any packages specified using
+	// paths like "./users" need to be resolved first.
+	importPaths := map[string]string{}
+	for _, packageName := range packageNames {
+		if !build.IsLocalImport(packageName) && !filepath.IsAbs(packageName) {
+			continue
+		}
+
+		paths, err := FindPackages(directory, packageName)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(paths) != 1 {
+			return nil, fmt.Errorf("the package '%s' must match exactly one package, but it matched %d", packageName, len(paths))
+		}
+
+		importPaths[packageName] = paths[0]
+	}
+
 	pkgs, err := packages.Load(&packages.Config{
 		Mode:  packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedFiles,
 		Dir:   directory,
 		Tests: true,
-	}, "pattern="+packageName)
+	}, slices.Map(packageNames, func(packageName string) string {
+		if importPath, ok := importPaths[packageName]; ok {
+			return "pattern=" + importPath
+		}
+
+		return "pattern=" + packageName
+	})...)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not load type information")
 	}
 
+	return &LoadedPackages{packages: pkgs, importPaths: importPaths}, nil
+}
+
+// Parse loads the specified package and parses it for the interfaces to mock. When parsing several
+// packages, it's quicker to load them together using Load.
+func Parse(packageName string, directory string, filter InterfaceFilter, options ...ParseOption) (*ParsedPackage, error) {
+	loaded, err := Load(directory, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	return loaded.Parse(packageName, filter, options...)
+}
+
+// Parse parses one of the loaded packages for the interfaces to mock.
+func (l *LoadedPackages) Parse(packageName string, filter InterfaceFilter, options ...ParseOption) (*ParsedPackage, error) {
+	opts := parseOptions{variant: PackageVariantTest}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	importPath := packageName
+	if path, ok := l.importPaths[packageName]; ok {
+		importPath = path
+	}
+
+	p := selectPackageVariant(l.packages, importPath, opts.variant)
+	if p == nil {
+		return nil, fmt.Errorf("could not find the '%s' variant of package '%s'", opts.variant, packageName)
+	}
+
 	var packageDirectory string
+	if len(p.GoFiles) > 0 {
+		packageDirectory = filepath.Dir(p.GoFiles[0])
+	}
+
+	var parseErr error
 	interfaces := map[string]MockedInterface{}
+	annotations := map[string]*MockAnnotation{}
 
-	for _, p := range pkgs {
-		if len(p.Syntax) > 0 && len(p.GoFiles) > 0 {
-			sourceDirectory := filepath.Dir(p.GoFiles[0])
-			if filepath.Base(sourceDirectory) == p.Name {
-				packageDirectory = filepath.Dir(p.GoFiles[0])
+	// We keep track of the order the mocks are found in so that the results are returned in the
+	// order the types are declared, keeping the output stable.
+	var names []string
+
+	for _, fileNode := range p.Syntax {
+		// Anything declared in a test file can only be referenced from other test files.
+		isTestFile := strings.HasSuffix(p.Fset.Position(fileNode.Pos()).Filename, "_test.go")
+		addMock := func(i MockedInterface) {
+			i.IsTestOnly = isTestFile
+			if _, ok := interfaces[i.FullName]; !ok {
+				names = append(names, i.FullName)
 			}
+
+			interfaces[i.FullName] = i
 		}
 
-		for _, fileNode := range p.Syntax {
-			ast.Inspect(fileNode, func(n ast.Node) bool {
-				if t, ok := n.(*ast.TypeSpec); ok {
-					if t.Name.IsExported() {
-						if interfaceType, ok := t.Type.(*ast.InterfaceType); ok {
-							if filter.Include(t.Name.Name) {
-								i := parseInterface(t.Name.Name, t.Name.Name, interfaceType, p, fileNode.Imports)
-								interfaces[i.FullName] = i
+		var declarationDoc *ast.CommentGroup
+		ast.Inspect(fileNode, func(n ast.Node) bool {
+			if genDecl, ok := n.(*ast.GenDecl); ok {
+				// The doc comment of a declaration containing a single type is attached to the
+				// declaration rather than the type spec.
+				declarationDoc = nil
+				if len(genDecl.Specs) == 1 {
+					declarationDoc = genDecl.Doc
+				}
+
+				return true
+			}
+
+			if t, ok := n.(*ast.TypeSpec); ok {
+				doc := t.Doc
+				if doc == nil {
+					doc = declarationDoc
+				}
+
+				annotation := parseAnnotation(doc)
+				if annotation != nil {
+					annotations[t.Name.Name] = annotation
+				}
+
+				filter := filter
+				if annotation != nil && opts.annotated {
+					filter = &AnyInterfaceFilter{Filters: []InterfaceFilter{filter, &IncludingInterfaceFilter{InterfacesToInclude: []string{t.Name.Name}}}}
+				}
+
+				// Unexported types can only be referenced by mocks generated in the same package.
+				if t.Name.IsExported() || opts.inPackage {
+					if interfaceType, ok := t.Type.(*ast.InterfaceType); ok {
+						if filter.Include(t.Name.Name) {
+							i := parseInterface(t.Name.Name, t.Name.Name, interfaceType, getTypeParams(p.TypesInfo.Defs[t.Name]), p, fileNode.Imports, opts)
+							addMock(i)
+						}
+
+						if t.TypeParams != nil {
+							instantiations, err := parseInstantiations(t, p, fileNode.Imports, opts, filter)
+							if err != nil {
+								parseErr = err
+								return false
 							}
-						} else if structType, ok := t.Type.(*ast.StructType); ok {
-							for _, f := range structType.Fields.List {
-								for _, i := range parseStructField(t, f, p, fileNode.Imports, filter) {
-									interfaces[i.FullName] = i
+
+							for _, i := range instantiations {
+								addMock(i)
+							}
+						}
+					} else if _, ok := t.Type.(*ast.FuncType); ok {
+						if includeType(filter, t.Name.Name) {
+							if f, ok := parseFunctionType(t, p, fileNode.Imports, opts); ok {
+								addMock(f)
+							}
+						}
+					} else if _, ok := t.Type.(*ast.StructType); ok {
+						if includeType(filter, t.Name.Name) {
+							if s, ok := parseStruct(t, p, fileNode.Imports, opts); ok {
+								addMock(s)
+							}
+						}
+
+						if structTypeInfo, ok := p.TypesInfo.Defs[t.Name]; ok {
+							if structType, ok := structTypeInfo.Type().Underlying().(*types.Struct); ok {
+								visited := map[*types.Named]bool{}
+								if namedType, ok := structTypeInfo.Type().(*types.Named); ok {
+									visited[namedType] = true
+								}
+
+								for _, i := range parseStructFields(t.Name.Name+".", structType, getTypeParams(structTypeInfo), p, opts, filter, visited) {
+									addMock(i)
 								}
 							}
 						}
-					}
+					} else if t.Assign.IsValid() {
+						// Aliases to interface literals are handled above, but aliases to named interfaces
+						// like "type Store = storage.Backend" need to be resolved using the type information.
+						if filter.Include(t.Name.Name) {
+							if a, ok := parseAlias(t, p, fileNode.Imports, opts); ok {
+								addMock(a)
+							}
+						}
 
-					// As soon as we've found a type, we don't need to continue traversing down
-					// this path of the tree since we'll already have gotten all the info we
-					// need from the type node by now.
-					return false
+						if t.TypeParams != nil {
+							instantiations, err := parseInstantiations(t, p, fileNode.Imports, opts, filter)
+							if err != nil {
+								parseErr = err
+								return false
+							}
+
+							for _, i := range instantiations {
+								addMock(i)
+							}
+						}
+					}
 				}
 
-				return true
-			})
+				// As soon as we've found a type, we don't need to continue traversing down
+				// this path of the tree since we'll already have gotten all the info we
+				// need from the type node by now.
+				return false
+			}
+
+			return true
+		})
+	}
+
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	mocks := slices.Map(names, func(name string) MockedInterface {
+		i := interfaces[name]
+		i.Annotation = annotations[name]
+		return i
+	})
+
+	return &ParsedPackage{PackageDirectory: packageDirectory, PackageName: p.Name, PackagePath: p.PkgPath, Mocks: mocks}, nil
+}
+
+// FindPackages returns the import paths of the packages matching the specified patterns, for example "./...".
+// The patterns are resolved relative to directory.
+func FindPackages(directory string, patterns ...string) ([]string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName,
+		Dir:  directory,
+	}, patterns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find packages")
+	}
+
+	var packagePaths []string
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			return nil, errors.Wrapf(p.Errors[0], "could not load package '%s'", p.PkgPath)
 		}
+
+		packagePaths = append(packagePaths, p.PkgPath)
 	}
 
-	return &ParsedPackage{PackageDirectory: packageDirectory, Mocks: maps.Values(interfaces)}, nil
+	return packagePaths, nil
 }
 
-func parseStructField(structNode *ast.TypeSpec, field *ast.Field, pkg *packages.Package, importSpecs []*ast.ImportSpec, filter InterfaceFilter) []MockedInterface {
+// FindTypeAfterLine finds the name of the type declared directly after the specified line of a Go
+// file. This is used to find the type below a go:generate directive. Returns an error if the next
+// declaration in the file isn't a single type declaration, or if the declaration and its doc comment
+// don't start on the line immediately after the specified line.
+func FindTypeAfterLine(filename string, line int) (string, error) {
+	fset := token.NewFileSet()
+	fileNode, err := goparser.ParseFile(fset, filename, nil, goparser.ParseComments)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not parse '%s'", filename)
+	}
+
+	for _, decl := range fileNode.Decls {
+		if fset.Position(decl.Pos()).Line <= line {
+			continue
+		}
+
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			break
+		}
+
+		// The doc comment of a declaration includes any comments directly above it, including the
+		// go:generate directive itself, so the type is directly below the line if its doc comment
+		// reaches the next line.
+		start := fset.Position(genDecl.Pos()).Line
+		if genDecl.Doc != nil {
+			start = min(start, fset.Position(genDecl.Doc.Pos()).Line)
+		}
+
+		if start > line+1 {
+			return "", fmt.Errorf("the type declared after line %d of '%s' must be directly below it", line, filename)
+		}
+
+		if len(genDecl.Specs) != 1 {
+			return "", fmt.Errorf("the declaration after line %d of '%s' contains %d types, but it must contain exactly one", line, filename, len(genDecl.Specs))
+		}
+
+		return genDecl.Specs[0].(*ast.TypeSpec).Name.Name, nil
+	}
+
+	return "", fmt.Errorf("could not find a type declared after line %d of '%s'", line, filename)
+}
+
+// selectPackageVariant finds the specified variant of the package from the list of packages loaded
+// by packages.Load.
+func selectPackageVariant(pkgs []*packages.Package, packageName string, variant PackageVariant) *packages.Package {
+	// The package itself uses its import path as its ID, whereas the variant including its test
+	// files has an ID like "example.com/users [example.com/users.test]". We need to match the IDs
+	// exactly, since when several packages are loaded together other variants can appear, for
+	// example "example.com/users [example.com/accounts.test]" if the accounts tests import users.
+	testBinary := packageName + ".test"
+	var library *packages.Package
+	for _, p := range pkgs {
+		switch {
+		case variant == PackageVariantExternalTest && p.ID == packageName+"_test ["+testBinary+"]":
+			return p
+		case variant == PackageVariantPackage && p.ID == packageName:
+			return p
+		case variant == PackageVariantTest && p.ID == packageName+" ["+testBinary+"]":
+			return p
+		case variant == PackageVariantTest && p.ID == packageName:
+			library = p
+		}
+	}
+
+	// Packages without any test files don't have a test variant, so we fall back to the
+	// package itself.
+	return library
+}
+
+func parseInstantiations(t *ast.TypeSpec, p *packages.Package, imports []*ast.ImportSpec, opts parseOptions, filter InterfaceFilter) ([]MockedInterface, error) {
+	instantiationFilter, ok := filter.(InstantiationFilter)
+	if !ok {
+		return nil, nil
+	}
+
 	var interfaces []MockedInterface
-	if structTypeInfo, ok := pkg.TypesInfo.Defs[structNode.Name]; ok {
-		if interfaceType, ok := field.Type.(*ast.InterfaceType); ok {
-			fullName := structTypeInfo.Name() + "." + field.Names[0].Name
+	for _, instantiation := range instantiationFilter.Instantiations(t.Name.Name) {
+		// Evaluating the instantiation at the position of the interface means that the type
+		// arguments are resolved in the scope of the file declaring the interface, allowing
+		// types from any of that file's imports to be used.
+		typeAndValue, err := types.Eval(p.Fset, p.Types, t.Pos(), instantiation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve generic interface instantiation '%s'", instantiation)
+		}
+
+		interfaceType, ok := typeAndValue.Type.Underlying().(*types.Interface)
+		if !typeAndValue.IsType() || !ok {
+			return nil, fmt.Errorf("'%s' is not an instantiation of a generic interface", instantiation)
+		}
+
+		importHelper := newImportHelper(imports, p, opts)
+		mockedInterface := MockedInterface{
+			Name:        t.Name.Name,
+			FullName:    instantiation,
+			PackageName: strings.ToLower(t.Name.Name),
+		}
+
+		for index := 0; index < interfaceType.NumMethods(); index++ {
+			method := interfaceType.Method(index)
+			mockedInterface.Methods = append(mockedInterface.Methods, parseMethod(method, findMethodComment(method, p), importHelper))
+		}
+
+		mockedInterface.Imports = importHelper.RequiredImports()
+		interfaces = append(interfaces, mockedInterface)
+	}
+
+	return interfaces, nil
+}
+
+// parseStructFields finds any interfaces nested inside the fields of a struct. As well as inline
+// struct and interface types, this follows fields that reference named struct types (including
+// pointers to them and embedded structs), so that paths like `Service.Deps.Store` can be mocked
+// even when `Deps` is declared elsewhere. The visited map prevents self-referencing structs from
+// causing infinite recursion.
+func parseStructFields(prefix string, structType *types.Struct, typeParams *types.TypeParamList, pkg *packages.Package, opts parseOptions, filter InterfaceFilter, visited map[*types.Named]bool) []MockedInterface {
+	var interfaces []MockedInterface
+	for index := 0; index < structType.NumFields(); index++ {
+		field := structType.Field(index)
+
+		// Embedded fields are named after their type, so `struct{ *Deps }` gives us a field called `Deps`.
+		fullName := prefix + field.Name()
+		// Fields declared using an alias, for example `D DepsAlias` where `type DepsAlias = Deps`, need
+		// to be resolved to the aliased type before we can tell what kind of type the field has.
+		fieldType := types.Unalias(field.Type())
+		if pointerType, ok := fieldType.(*types.Pointer); ok {
+			fieldType = types.Unalias(pointerType.Elem())
+		}
+
+		switch t := fieldType.(type) {
+		case *types.Interface:
 			if filter.Include(fullName) {
-				parsedInterface := parseInterface(field.Names[0].Name, fullName, interfaceType, pkg, importSpecs)
-				interfaces = append(interfaces, parsedInterface)
+				interfaces = append(interfaces, parseFieldInterface(field, fullName, t, typeParams, pkg, opts))
 			}
-		} else if structType, ok := field.Type.(*ast.StructType); ok {
-			for _, f := range structType.Fields.List {
-				interfaces = append(interfaces, parseNestedStructField(structTypeInfo.Name()+"."+field.Names[0].Name+".", f, pkg, importSpecs, filter)...)
+		case *types.Struct:
+			interfaces = append(interfaces, parseStructFields(fullName+".", t, typeParams, pkg, opts, filter, visited)...)
+		case *types.Named:
+			nestedStruct, ok := t.Underlying().(*types.Struct)
+			if !ok || visited[t.Origin()] {
+				continue
 			}
+
+			visited[t.Origin()] = true
+			interfaces = append(interfaces, parseStructFields(fullName+".", nestedStruct, typeParams, pkg, opts, filter, visited)...)
+			delete(visited, t.Origin())
 		}
 	}
 
 	return interfaces
 }
 
-func parseNestedStructField(prefix string, field *ast.Field, pkg *packages.Package, importSpecs []*ast.ImportSpec, filter InterfaceFilter) []MockedInterface {
-	var interfaces []MockedInterface
-	if interfaceType, ok := field.Type.(*ast.InterfaceType); ok {
-		fullName := prefix + field.Names[0].Name
-		if filter.Include(fullName) {
-			parsedInterface := parseInterface(field.Names[0].Name, fullName, interfaceType, pkg, importSpecs)
-			interfaces = append(interfaces, parsedInterface)
+// parseFieldInterface parses an interface declared inline as the type of a struct field. When the
+// field is declared in the package being parsed we use its syntax so that the methods are generated
+// in declaration order along with their comments. Otherwise we fall back to the type information.
+func parseFieldInterface(field *types.Var, fullName string, interfaceType *types.Interface, typeParams *types.TypeParamList, pkg *packages.Package, opts parseOptions) MockedInterface {
+	if fieldNode, fileNode := findField(field, pkg); fieldNode != nil {
+		if interfaceNode, ok := fieldNode.Type.(*ast.InterfaceType); ok {
+			return parseInterface(field.Name(), fullName, interfaceNode, typeParams, pkg, fileNode.Imports, opts)
 		}
-	} else if structType, ok := field.Type.(*ast.StructType); ok {
-		for _, f := range structType.Fields.List {
-			interfaces = append(interfaces, parseNestedStructField(prefix+field.Names[0].Name+".", f, pkg, importSpecs, filter)...)
+	}
+
+	importHelper := newImportHelper(nil, pkg, opts)
+	mockedInterface := MockedInterface{
+		Name:           field.Name(),
+		FullName:       fullName,
+		PackageName:    strings.ToLower(field.Name()),
+		TypeParameters: parseTypeParams(typeParams, importHelper),
+	}
+
+	for index := 0; index < interfaceType.NumMethods(); index++ {
+		method := interfaceType.Method(index)
+		mockedInterface.Methods = append(mockedInterface.Methods, parseMethod(method, findMethodComment(method, pkg), importHelper))
+	}
+
+	mockedInterface.Imports = importHelper.RequiredImports()
+
+	return mockedInterface
+}
+
+// findField finds the syntax node declaring the specified struct field, along with the file that
+// contains it. Returns nil if the field isn't declared in the package being parsed.
+func findField(field *types.Var, p *packages.Package) (*ast.Field, *ast.File) {
+	for _, fileNode := range p.Syntax {
+		if fileNode.Pos() > field.Pos() || fileNode.End() < field.Pos() {
+			continue
+		}
+
+		var fieldNode *ast.Field
+		ast.Inspect(fileNode, func(n ast.Node) bool {
+			if f, ok := n.(*ast.Field); ok && slices.Contains(f.Names, func(name *ast.Ident) bool { return name.Pos() == field.Pos() }) {
+				fieldNode = f
+			}
+
+			return fieldNode == nil
+		})
+
+		if fieldNode != nil {
+			return fieldNode, fileNode
 		}
 	}
 
-	return interfaces
+	return nil, nil
 }
 
-func parseInterface(name, fullName string, i *ast.InterfaceType, p *packages.Package, imports []*ast.ImportSpec) MockedInterface {
-	importHelper := newImportHelper(p.TypesInfo, imports, p)
+func parseInterface(name, fullName string, i *ast.InterfaceType, typeParams *types.TypeParamList, p *packages.Package, imports []*ast.ImportSpec, opts parseOptions) MockedInterface {
+	importHelper := newImportHelper(imports, p, opts)
 	mockedInterface := MockedInterface{
 		Name:        name,
 		FullName:    fullName,
 		PackageName: strings.ToLower(name),
 	}
 
+	mockedInterface.TypeParameters = parseTypeParams(typeParams, importHelper)
+
+	// We walk the syntax tree rather than just using the interface's method set so that the methods
+	// are generated in the order they're declared in, and so that we can easily access their comments.
+	// The method signatures themselves come from the type information.
+	methodNames := map[string]bool{}
 	for _, method := range i.Methods.List {
-		methodDefinition := MethodDefinition{
-			Name:    method.Names[0].Name,
-			Comment: strings.TrimSuffix(method.Doc.Text(), "\n"),
-		}
-
-		funcType := method.Type.(*ast.FuncType)
-		for paramIndex, param := range funcType.Params.List {
-			if len(param.Names) > 0 {
-				for _, paramName := range param.Names {
-					typeInfo := getTypeInfo(param.Type, p)
-					methodDefinition.Parameters = append(methodDefinition.Parameters, ParameterDefinition{
-						Name:                paramName.Name,
-						Type:                typeInfo.name,
-						IsVariadic:          typeInfo.isVariadic,
-						IsNonEmptyInterface: typeInfo.isNonEmptyInterface,
-					})
-				}
-			} else {
-				typeInfo := getTypeInfo(param.Type, p)
-				methodDefinition.Parameters = append(methodDefinition.Parameters, ParameterDefinition{
-					Name:                "_p" + strconv.Itoa(paramIndex),
-					Type:                typeInfo.name,
-					IsVariadic:          typeInfo.isVariadic,
-					IsNonEmptyInterface: typeInfo.isNonEmptyInterface,
-				})
+		var methodDefinitions []MethodDefinition
+		if len(method.Names) == 0 {
+			// This is an embedded interface, so rather than walking the syntax tree we use the
+			// type information to get its full method set. This means that interfaces embedded
+			// from other packages, as well as interfaces that themselves embed other interfaces
+			// are handled without needing access to their source.
+			methodDefinitions = parseEmbeddedInterface(method.Type, p, importHelper)
+		} else if methodType, ok := p.TypesInfo.Defs[method.Names[0]].(*types.Func); ok {
+			methodDefinitions = append(methodDefinitions, parseMethod(methodType, strings.TrimSuffix(method.Doc.Text(), "\n"), importHelper))
+		}
+
+		// Go allows the same method to be included multiple times via embedding as long as the
+		// signatures are identical, so we only need to include each method once.
+		for _, methodDefinition := range methodDefinitions {
+			if !methodNames[methodDefinition.Name] {
+				methodNames[methodDefinition.Name] = true
+				mockedInterface.Methods = append(mockedInterface.Methods, methodDefinition)
 			}
+		}
+	}
 
-			importHelper.AddImportsRequiredForType(param.Type)
+	mockedInterface.Imports = importHelper.RequiredImports()
+
+	return mockedInterface
+}
+
+func parseStruct(t *ast.TypeSpec, p *packages.Package, imports []*ast.ImportSpec, opts parseOptions) (MockedInterface, bool) {
+	typeName, ok := p.TypesInfo.Defs[t.Name].(*types.TypeName)
+	if !ok {
+		return MockedInterface{}, false
+	}
+
+	namedType, ok := typeName.Type().(*types.Named)
+	if !ok {
+		return MockedInterface{}, false
+	}
+
+	importHelper := newImportHelper(imports, p, opts)
+	mockedStruct := MockedInterface{
+		Name:           t.Name.Name,
+		FullName:       t.Name.Name,
+		PackageName:    strings.ToLower(t.Name.Name),
+		TypeParameters: parseTypeParams(namedType.TypeParams(), importHelper),
+	}
+
+	// The methods of a generic struct use the type parameters declared by their receivers, which
+	// don't have to have the same names as the struct's type parameters. Instantiating the struct
+	// using its own type parameters gives us method signatures that consistently use the names
+	// from the struct declaration.
+	var structType types.Type = namedType
+	if namedType.TypeParams().Len() > 0 {
+		typeArgs := make([]types.Type, namedType.TypeParams().Len())
+		for index := range typeArgs {
+			typeArgs[index] = namedType.TypeParams().At(index)
 		}
 
-		if funcType.Results != nil {
-			for _, result := range funcType.Results.List {
-				if len(result.Names) > 0 {
-					typeInfo := getTypeInfo(result.Type, p)
-					for _, resultName := range result.Names {
-						methodDefinition.Results = append(methodDefinition.Results, ResultDefinition{
-							Name: resultName.Name,
-							Type: typeInfo.name,
-						})
-					}
-				} else {
-					typeInfo := getTypeInfo(result.Type, p)
-					methodDefinition.Results = append(methodDefinition.Results, ResultDefinition{
-						Type: typeInfo.name,
+		instantiated, err := types.Instantiate(nil, namedType, typeArgs, false)
+		if err != nil {
+			return MockedInterface{}, false
+		}
+
+		structType = instantiated
+	}
+
+	// We use the method set of a pointer to the struct since that includes the methods with both
+	// value and pointer receivers, as well as any methods promoted from embedded fields.
+	methodSet := types.NewMethodSet(types.NewPointer(structType))
+	for index := 0; index < methodSet.Len(); index++ {
+		method, ok := methodSet.At(index).Obj().(*types.Func)
+		if !ok || !method.Exported() {
+			continue
+		}
+
+		mockedStruct.Methods = append(mockedStruct.Methods, parseMethod(method, findMethodComment(method, p), importHelper))
+	}
+
+	// Structs without any exported methods are typically just used to hold data, and there's
+	// nothing to mock.
+	if len(mockedStruct.Methods) == 0 {
+		return MockedInterface{}, false
+	}
+
+	mockedStruct.StructType = "*" + types.TypeString(structType, importHelper.Qualifier)
+	mockedStruct.Imports = importHelper.RequiredImports()
+
+	return mockedStruct, true
+}
+
+// functionTypeMethodName is the name of the method used to mock calls to a function type.
+const functionTypeMethodName = "Call"
+
+func parseFunctionType(t *ast.TypeSpec, p *packages.Package, imports []*ast.ImportSpec, opts parseOptions) (MockedInterface, bool) {
+	typeName, ok := p.TypesInfo.Defs[t.Name].(*types.TypeName)
+	if !ok {
+		return MockedInterface{}, false
+	}
+
+	signature, ok := typeName.Type().Underlying().(*types.Signature)
+	if !ok {
+		return MockedInterface{}, false
+	}
+
+	importHelper := newImportHelper(imports, p, opts)
+	mockedFunction := MockedInterface{
+		Name:        t.Name.Name,
+		FullName:    t.Name.Name,
+		PackageName: strings.ToLower(t.Name.Name),
+		Methods:     []MethodDefinition{parseSignature(functionTypeMethodName, signature, "", importHelper)},
+	}
+
+	mockedFunction.TypeParameters = parseTypeParams(getTypeParams(typeName), importHelper)
+
+	// The function type is always declared in the package being parsed. For generic function types,
+	// the mock's own type parameters are passed as type arguments, giving us something like `auth.Check[T]`.
+	mockedFunction.FunctionType = typeName.Name()
+	if qualifier := importHelper.Qualifier(typeName.Pkg()); qualifier != "" {
+		mockedFunction.FunctionType = qualifier + "." + mockedFunction.FunctionType
+	}
+
+	if len(mockedFunction.TypeParameters) > 0 {
+		mockedFunction.FunctionType += "[" + strings.Join(slices.Map(mockedFunction.TypeParameters, func(t TypeParameterDefinition) string { return t.Name }), ", ") + "]"
+	}
+	mockedFunction.Imports = importHelper.RequiredImports()
+
+	return mockedFunction, true
+}
+
+// parseAlias parses a type alias that refers to an interface, for example "type Store = storage.Backend"
+// or "type UserCache = Cache[string, User]". The mock contains the method set of the aliased interface.
+func parseAlias(t *ast.TypeSpec, p *packages.Package, imports []*ast.ImportSpec, opts parseOptions) (MockedInterface, bool) {
+	typeName, ok := p.TypesInfo.Defs[t.Name].(*types.TypeName)
+	if !ok {
+		return MockedInterface{}, false
+	}
+
+	interfaceType, ok := typeName.Type().Underlying().(*types.Interface)
+	if !ok {
+		return MockedInterface{}, false
+	}
+
+	importHelper := newImportHelper(imports, p, opts)
+	mockedInterface := MockedInterface{
+		Name:        t.Name.Name,
+		FullName:    t.Name.Name,
+		PackageName: strings.ToLower(t.Name.Name),
+	}
+
+	// Generic aliases declare their own type parameters, which the aliased interface is instantiated with.
+	if t.TypeParams != nil {
+		for _, field := range t.TypeParams.List {
+			for _, name := range field.Names {
+				if typeParam, ok := p.TypesInfo.Defs[name].Type().(*types.TypeParam); ok {
+					mockedInterface.TypeParameters = append(mockedInterface.TypeParameters, TypeParameterDefinition{
+						Name:       typeParam.Obj().Name(),
+						Constraint: types.TypeString(typeParam.Constraint(), importHelper.Qualifier),
 					})
 				}
-
-				importHelper.AddImportsRequiredForType(result.Type)
 			}
 		}
+	}
 
-		mockedInterface.Methods = append(mockedInterface.Methods, methodDefinition)
+	for index := 0; index < interfaceType.NumMethods(); index++ {
+		method := interfaceType.Method(index)
+		mockedInterface.Methods = append(mockedInterface.Methods, parseMethod(method, findMethodComment(method, p), importHelper))
 	}
 
 	mockedInterface.Imports = importHelper.RequiredImports()
 
-	return mockedInterface
+	return mockedInterface, true
+}
+
+func parseEmbeddedInterface(e ast.Expr, p *packages.Package, importHelper *importHelper) []MethodDefinition {
+	embeddedType := p.TypesInfo.TypeOf(e)
+	if embeddedType == nil {
+		return nil
+	}
+
+	// Interfaces used as type constraints can embed non-interface types (e.g. `~int | ~string`),
+	// but those don't contribute any methods, so we can safely ignore them.
+	interfaceType, ok := embeddedType.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+
+	var methods []MethodDefinition
+	for index := 0; index < interfaceType.NumMethods(); index++ {
+		method := interfaceType.Method(index)
+		methods = append(methods, parseMethod(method, findMethodComment(method, p), importHelper))
+	}
+
+	return methods
 }
 
-type typeInfo struct {
-	name                string
-	isVariadic          bool
-	isNonEmptyInterface bool
+func parseMethod(method *types.Func, comment string, importHelper *importHelper) MethodDefinition {
+	return parseSignature(method.Name(), method.Type().(*types.Signature), comment, importHelper)
 }
 
-func getTypeInfo(e ast.Expr, p *packages.Package) typeInfo {
-	if ellipsis, ok := e.(*ast.Ellipsis); ok {
-		return typeInfo{
-			name:       getTypeName(ellipsis.Elt, p),
-			isVariadic: true,
+func parseSignature(name string, signature *types.Signature, comment string, importHelper *importHelper) MethodDefinition {
+	methodDefinition := MethodDefinition{
+		Name:    name,
+		Comment: comment,
+	}
+
+	for index := 0; index < signature.Params().Len(); index++ {
+		param := signature.Params().At(index)
+		paramType := param.Type()
+		isVariadic := signature.Variadic() && index == signature.Params().Len()-1
+		if isVariadic {
+			paramType = paramType.(*types.Slice).Elem()
 		}
+
+		paramName := param.Name()
+		if paramName == "" || paramName == "_" {
+			paramName = "_p" + strconv.Itoa(index)
+		}
+
+		methodDefinition.Parameters = append(methodDefinition.Parameters, ParameterDefinition{
+			Name:                paramName,
+			Type:                types.TypeString(paramType, importHelper.Qualifier),
+			IsVariadic:          isVariadic,
+			IsNonEmptyInterface: isNonEmptyInterface(paramType),
+			IsTypeParameter:     isTypeParameter(paramType),
+		})
 	}
 
-	return typeInfo{
-		name:                getTypeName(e, p),
-		isNonEmptyInterface: isNonEmptyInterface(e, p),
+	for index := 0; index < signature.Results().Len(); index++ {
+		result := signature.Results().At(index)
+		methodDefinition.Results = append(methodDefinition.Results, ResultDefinition{
+			Name:               result.Name(),
+			Type:               types.TypeString(result.Type(), importHelper.Qualifier),
+			ChannelElementType: getChannelElementType(result.Type(), importHelper),
+		})
 	}
+
+	return methodDefinition
 }
 
-func getTypeName(e ast.Expr, p *packages.Package) string {
-	switch n := e.(type) {
-	case *ast.Ident:
-		// Check if this is a type rather than, for example, a package name.
-		if _, ok := p.TypesInfo.Types[e]; ok {
-			if use, ok := p.TypesInfo.Uses[n]; ok {
-				typePackage := use.Pkg()
-				if typePackage != nil && typePackage.Path() == p.PkgPath {
-					// If the type's package matches the package we're parsing, this is a reference
-					// to a type in the same package. We'll need to adjust the type name to include
-					// the package name so that it can be referenced correctly from the package
-					// generated for the mock.
-					return p.Name + "." + n.Name
-				}
-			}
+// findMethodComment finds the doc comment for the specified method. Comments are only available
+// for methods declared in the package being parsed, since we don't load the syntax of any
+// dependencies.
+func findMethodComment(method *types.Func, p *packages.Package) string {
+	var comment string
+	found := false
+	for _, fileNode := range p.Syntax {
+		if fileNode.Pos() > method.Pos() || fileNode.End() < method.Pos() {
+			continue
 		}
 
-		return n.Name
-	case *ast.ArrayType:
-		elementType := getTypeName(n.Elt, p)
-		return "[]" + elementType
-	case *ast.StarExpr:
-		return "*" + getTypeName(n.X, p)
-	case *ast.SelectorExpr:
-		packageName := getTypeName(n.X, p)
-
-		return packageName + "." + n.Sel.Name
-	case *ast.MapType:
-		keyType := getTypeName(n.Key, p)
-		valueType := getTypeName(n.Value, p)
-
-		return "map[" + keyType + "]" + valueType
-	case *ast.FuncType:
-		var params []string
-		for _, param := range n.Params.List {
-			parameterNames := slices.Map(param.Names, func(i *ast.Ident) string { return i.Name })
-			if len(parameterNames) > 0 {
-				params = append(params, strings.Join(parameterNames, ", ")+" "+getTypeName(param.Type, p))
-			} else {
-				params = append(params, getTypeName(param.Type, p))
+		ast.Inspect(fileNode, func(n ast.Node) bool {
+			if field, ok := n.(*ast.Field); ok && len(field.Names) > 0 && field.Names[0].Pos() == method.Pos() {
+				comment = strings.TrimSuffix(field.Doc.Text(), "\n")
+				found = true
+			} else if funcDecl, ok := n.(*ast.FuncDecl); ok && funcDecl.Name.Pos() == method.Pos() {
+				comment = strings.TrimSuffix(funcDecl.Doc.Text(), "\n")
+				found = true
 			}
-		}
 
-		var results []string
-		if n.Results != nil {
-			for _, result := range n.Results.List {
-				resultNames := slices.Map(result.Names, func(i *ast.Ident) string { return i.Name })
-				if len(resultNames) > 0 {
-					results = append(results, strings.Join(resultNames, ", ")+" "+getTypeName(result.Type, p))
-				} else {
-					results = append(results, getTypeName(result.Type, p))
-				}
-			}
-		}
+			return !found
+		})
+	}
 
-		functionDefinition := "func(" + strings.Join(params, ", ") + ")"
-		if len(results) > 0 {
-			functionDefinition += " (" + strings.Join(results, ", ") + ")"
-		}
+	return comment
+}
 
-		return functionDefinition
-	case *ast.InterfaceType:
-		// This is maybe a bit of a simplification. We might need to actually take a look at the fields.
-		return "interface{}"
+func getChannelElementType(t types.Type, importHelper *importHelper) string {
+	if channelType, ok := t.Underlying().(*types.Chan); ok && channelType.Dir() != types.SendOnly {
+		return types.TypeString(channelType.Elem(), importHelper.Qualifier)
 	}
 
-	panic(fmt.Sprintf("Unknown type %v. This is a bug in Kelpie!", e))
+	return ""
 }
 
-func isNonEmptyInterface(e ast.Expr, p *packages.Package) bool {
-	if t, ok := p.TypesInfo.Types[e]; ok {
-		if namedType, ok := t.Type.(*types.Named); ok {
-			if i, ok := namedType.Underlying().(*types.Interface); ok {
-				return !i.Empty()
-			}
-		}
+func parseTypeParams(typeParams *types.TypeParamList, importHelper *importHelper) []TypeParameterDefinition {
+	var definitions []TypeParameterDefinition
+	for index := 0; index < typeParams.Len(); index++ {
+		typeParam := typeParams.At(index)
+		definitions = append(definitions, TypeParameterDefinition{
+			Name:       typeParam.Obj().Name(),
+			Constraint: types.TypeString(typeParam.Constraint(), importHelper.Qualifier),
+		})
+	}
+
+	return definitions
+}
+
+// getTypeParams returns the type parameters of the specified type, or nil if the type isn't generic.
+func getTypeParams(typeName types.Object) *types.TypeParamList {
+	if typeName == nil {
+		return nil
+	}
+
+	if namedType, ok := typeName.Type().(*types.Named); ok {
+		return namedType.TypeParams()
+	}
+
+	return nil
+}
+
+func isTypeParameter(t types.Type) bool {
+	_, ok := t.(*types.TypeParam)
+	return ok
+}
+
+// isNonEmptyInterface returns true if the type is an interface with at least one method. This
+// includes named interfaces like `io.Reader` as well as interface literals like
+// `interface{ Handle(Event) error }`.
+func isNonEmptyInterface(t types.Type) bool {
+	// The underlying type of a type parameter is its constraint, so we need to make sure we
+	// don't treat type parameters constrained by an interface as interfaces themselves.
+	if isTypeParameter(t) {
+		return false
+	}
+
+	if i, ok := t.Underlying().(*types.Interface); ok {
+		return !i.Empty()
 	}
 
 	return false
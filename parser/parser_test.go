@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/adamconnelly/kelpie"
+	"github.com/adamconnelly/kelpie/internal/testmodule"
 	"github.com/adamconnelly/kelpie/parser"
 	"github.com/adamconnelly/kelpie/parser/mocks/interfacefilter"
 	"github.com/adamconnelly/kelpie/slices"
@@ -341,6 +342,150 @@ type Requester interface {
 	t.Contains(requester.Imports, `h "net/http"`)
 }
 
+func (t *ParserTests) Test_Parse_AliasesPackagesWithTheSameName() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"api/v1/types.go": `package v1
+
+type User struct {
+	Name string
+}`,
+		"core/v1/types.go": `package v1
+
+type Pod struct {
+	Name string
+}`,
+		"users/users.go": `package users
+
+import "github.com/adamconnelly/kelpie-test/api/v1"
+
+type UserService interface {
+	GetUser(id int) v1.User
+}`,
+		"pods/pods.go": `package pods
+
+import (
+	"github.com/adamconnelly/kelpie-test/core/v1"
+	"github.com/adamconnelly/kelpie-test/users"
+)
+
+type PodService interface {
+	users.UserService
+	GetPod(name string) v1.Pod
+}`,
+	})
+
+	// Act
+	result, err := parser.Parse("github.com/adamconnelly/kelpie-test/pods", moduleDir, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	podService := result.Mocks[0]
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/api/v1"`, `corev1 "github.com/adamconnelly/kelpie-test/core/v1"`}, podService.Imports)
+
+	getUser := slices.FirstOrPanic(podService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "GetUser" })
+	t.Equal("v1.User", getUser.Results[0].Type)
+
+	getPod := slices.FirstOrPanic(podService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "GetPod" })
+	t.Equal("corev1.Pod", getPod.Results[0].Type)
+}
+
+func (t *ParserTests) Test_Parse_AliasesPackagesWithMajorVersionSuffixes() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"lib/lib.go": `package lib
+
+type Options struct{}`,
+		"lib/v2/lib.go": `package lib
+
+type Options struct{}`,
+		"v2service/v2service.go": `package v2service
+
+import "github.com/adamconnelly/kelpie-test/lib/v2"
+
+type Service interface {
+	Configure(options lib.Options)
+}`,
+		"service/service.go": `package service
+
+import (
+	"github.com/adamconnelly/kelpie-test/lib"
+	"github.com/adamconnelly/kelpie-test/v2service"
+)
+
+type Service interface {
+	Setup(options lib.Options)
+	v2service.Service
+}`,
+	})
+
+	// Act
+	result, err := parser.Parse("github.com/adamconnelly/kelpie-test/service", moduleDir, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	service := result.Mocks[0]
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/lib"`, `kelpietestlib "github.com/adamconnelly/kelpie-test/lib/v2"`}, service.Imports)
+
+	configure := slices.FirstOrPanic(service.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Configure" })
+	t.Equal("kelpietestlib.Options", configure.Parameters[0].Type)
+}
+
+func (t *ParserTests) Test_Parse_SortsAliasedImportsByPath() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"internal/mocking/mocking.go": `package mocking
+
+type Options struct{}`,
+		"service/service.go": `package service
+
+import (
+	"io"
+
+	"github.com/adamconnelly/kelpie-test/internal/mocking"
+)
+
+type Service interface {
+	Configure(r io.Reader, options mocking.Options)
+}`,
+	})
+
+	// Act
+	result, err := parser.Parse("github.com/adamconnelly/kelpie-test/service", moduleDir, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Equal([]string{`internalmocking "github.com/adamconnelly/kelpie-test/internal/mocking"`, `"io"`}, result.Mocks[0].Imports)
+}
+
+func (t *ParserTests) Test_Parse_AliasesPackagesWithTheSameNameAsKelpiePackages() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"internal/mocking/mocking.go": `package mocking
+
+type Options struct{}`,
+		"service/service.go": `package service
+
+import "github.com/adamconnelly/kelpie-test/internal/mocking"
+
+type Service interface {
+	Configure(options mocking.Options)
+}`,
+	})
+
+	// Act
+	result, err := parser.Parse("github.com/adamconnelly/kelpie-test/service", moduleDir, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	service := result.Mocks[0]
+	t.Equal([]string{`internalmocking "github.com/adamconnelly/kelpie-test/internal/mocking"`}, service.Imports)
+	t.Equal("internalmocking.Options", service.Methods[0].Parameters[0].Type)
+}
+
 func (t *ParserTests) Test_Parse_SupportsMaps() {
 	// Arrange
 	input := `package test
@@ -604,6 +749,105 @@ type SecretsManager interface {
 	t.True(putSecret.Parameters[2].IsNonEmptyInterface)
 }
 
+func (t *ParserTests) Test_Parse_MarksVariadicNonEmptyInterfaceParameters() {
+	// Arrange
+	input := `package archiver
+
+import "io"
+
+type Archiver interface {
+	Archive(destination io.Writer, sources ...io.Reader) error
+	Tag(name string, tags ...string) error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("archiver", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	archiver := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "Archiver" })
+
+	archive := slices.FirstOrPanic(archiver.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Archive" })
+	t.Equal("sources", archive.Parameters[1].Name)
+	t.Equal("io.Reader", archive.Parameters[1].Type)
+	t.True(archive.Parameters[1].IsVariadic)
+	t.True(archive.Parameters[1].IsNonEmptyInterface)
+
+	tag := slices.FirstOrPanic(archiver.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Tag" })
+	t.Equal("tags", tag.Parameters[1].Name)
+	t.True(tag.Parameters[1].IsVariadic)
+	t.False(tag.Parameters[1].IsNonEmptyInterface)
+}
+
+func (t *ParserTests) Test_Parse_SupportsInlineInterfaceParameters() {
+	// Arrange
+	input := `package events
+
+import (
+	"io"
+	"net/http"
+)
+
+type Event struct{}
+
+type EventBus interface {
+	Register(handler interface{ Handle(event Event) error })
+	Serve(handler interface {
+		io.Closer
+		ServeHTTP(w http.ResponseWriter, r *http.Request)
+	}) interface{ Wait() error }
+	Publish(event interface{}) error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("events", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	eventBus := result.Mocks[0]
+
+	register := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Register" })
+	t.Equal("interface{Handle(event events.Event) error}", register.Parameters[0].Type)
+	t.True(register.Parameters[0].IsNonEmptyInterface)
+
+	serve := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Serve" })
+	t.Equal("interface{ServeHTTP(w http.ResponseWriter, r *http.Request); io.Closer}", serve.Parameters[0].Type)
+	t.True(serve.Parameters[0].IsNonEmptyInterface)
+	t.Equal("interface{Wait() error}", serve.Results[0].Type)
+
+	publish := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Publish" })
+	t.Equal("interface{}", publish.Parameters[0].Type)
+	t.False(publish.Parameters[0].IsNonEmptyInterface)
+
+	t.Len(eventBus.Imports, 3)
+	t.Contains(eventBus.Imports, `"io"`)
+	t.Contains(eventBus.Imports, `"net/http"`)
+	t.Contains(eventBus.Imports, `"github.com/adamconnelly/kelpie-test/events"`)
+}
+
+func (t *ParserTests) Test_Parse_DoesNotMarkTypeParametersConstrainedByInterfacesAsNonEmptyInterfaces() {
+	// Arrange
+	input := `package printing
+
+import "fmt"
+
+type Printer[T fmt.Stringer] interface {
+	Print(value T)
+}`
+
+	// Act
+	result, _, err := t.ParseInput("printing", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	printMethod := result.Mocks[0].Methods[0]
+	t.True(printMethod.Parameters[0].IsTypeParameter)
+	t.False(printMethod.Parameters[0].IsNonEmptyInterface)
+}
+
 func (t *ParserTests) Test_Parse_SupportsNestedInterfaces() {
 	// Arrange
 	input := `package config
@@ -689,100 +933,1755 @@ type DoubleNested struct {
 	t.Len(DoSomethingElse.Results, 2)
 }
 
-func (t *ParserTests) Test_Parse_CanParseStdlibInterfaces() {
+func (t *ParserTests) Test_Parse_SupportsInterfacesInNamedStructFields() {
 	// Arrange
+	files := map[string]string{
+		"service.go": `package users
+
+type Service struct {
+	Deps Deps
+	Config *Config
+}`,
+		"deps.go": `package users
+
+type Deps struct {
+	// Store stores users.
+	Store interface {
+		// Save saves a user.
+		Save(name string) error
+		Delete(name string) error
+	}
+}
+
+type Config struct {
+	Source interface {
+		Load() (string, error)
+	}
+}`,
+	}
+
 	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
-	t.interfaceFilter.Setup(interfacefilter.Include("Reader").Return(true))
+	t.interfaceFilter.Setup(interfacefilter.Include("Service.Deps.Store").Return(true))
+	t.interfaceFilter.Setup(interfacefilter.Include("Service.Config.Source").Return(true))
 
 	// Act
-	result, err := parser.Parse("io", ".", t.interfaceFilter.Instance())
+	result, _, err := t.ParseFiles("users", files, t.interfaceFilter.Instance())
 
 	// Assert
 	t.NoError(err)
-	t.Len(result.Mocks, 1)
-	t.Len(result.Mocks[0].Methods, 1)
-	t.Equal("Read", result.Mocks[0].Methods[0].Name)
+	t.Len(result.Mocks, 2)
+
+	store := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.FullName == "Service.Deps.Store" })
+	t.Equal("Store", store.Name)
+	t.Equal("store", store.PackageName)
+	t.Len(store.Methods, 2)
+	t.Equal("Save", store.Methods[0].Name)
+	t.Equal("Save saves a user.", store.Methods[0].Comment)
+	t.Equal("Delete", store.Methods[1].Name)
+
+	source := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.FullName == "Service.Config.Source" })
+	t.Equal("Source", source.Name)
+	t.Len(source.Methods, 1)
 }
 
-func (t *ParserTests) Test_MockedInterface_AnyMethodsHaveParameters_ReturnsFalseIfNoMethodsHaveParameters() {
+func (t *ParserTests) Test_Parse_SupportsInterfacesInAliasedStructFields() {
 	// Arrange
-	mockedInterface := parser.MockedInterface{
-		Methods: []parser.MethodDefinition{
-			{
-				Name: "MethodWithoutParameters",
-			},
-		},
+	input := `package users
+
+type Deps struct {
+	Store interface {
+		Save(name string) error
 	}
+}
+
+type DepsAlias = Deps
+
+type Service struct {
+	D DepsAlias
+	P *DepsAlias
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Service.D.Store").Return(true))
+	t.interfaceFilter.Setup(interfacefilter.Include("Service.P.Store").Return(true))
 
 	// Act
-	hasParameters := mockedInterface.AnyMethodsHaveParameters()
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
 
 	// Assert
-	t.False(hasParameters)
+	t.NoError(err)
+	t.Len(result.Mocks, 2)
+
+	named := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.FullName == "Service.D.Store" })
+	t.Equal("Store", named.Name)
+	t.Len(named.Methods, 1)
+
+	pointer := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.FullName == "Service.P.Store" })
+	t.Equal("Store", pointer.Name)
+	t.Len(pointer.Methods, 1)
 }
 
-func (t *ParserTests) Test_MockedInterface_AnyMethodsHaveParameters_ReturnsTrueIfAtLeastOneMethodHasParameters() {
+func (t *ParserTests) Test_Parse_SupportsInterfacesInEmbeddedStructs() {
 	// Arrange
-	mockedInterface := parser.MockedInterface{
-		Methods: []parser.MethodDefinition{
-			{
-				Name: "MethodWithoutParameters",
-			},
-			{
-				Name: "MethodWithParameters",
-				Parameters: []parser.ParameterDefinition{
-					{
-						Name: "value",
-					},
-				},
-			},
-		},
+	input := `package users
+
+type Deps struct {
+	Store interface {
+		Save(name string) error
 	}
+}
+
+type Service struct {
+	*Deps
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Service.Deps.Store").Return(true))
 
 	// Act
-	hasParameters := mockedInterface.AnyMethodsHaveParameters()
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
 
 	// Assert
-	t.True(hasParameters)
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Equal("Service.Deps.Store", result.Mocks[0].FullName)
+	t.Equal("Store", result.Mocks[0].Name)
 }
 
-// TODO: add a test for handling types that can't be resolved (e.g. because of a mistake in the code we're parsing)
-// TODO: what about empty interfaces? Return a warning?
+func (t *ParserTests) Test_Parse_SupportsFieldsWithMultipleNames() {
+	// Arrange
+	input := `package users
 
-func (t *ParserTests) ParseInput(packageName, input string, filter parser.InterfaceFilter) (*parser.ParsedPackage, *string, error) {
-	tmpDir, err := os.MkdirTemp("", "kelpie-parser-tests")
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not create temp dir for module")
+type Service struct {
+	Primary, Replica interface {
+		Save(name string) error
 	}
-	defer os.RemoveAll(tmpDir)
+}`
 
-	packageDir := filepath.Join(tmpDir, packageName)
+	// Act
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
 
-	if err := os.Mkdir(packageDir, os.ModePerm); err != nil {
-		return nil, nil, errors.Wrap(err, "could not create package directory")
-	}
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 2)
 
-	goMod, err := os.Create(filepath.Join(tmpDir, "go.mod"))
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not create file for go.mod")
-	}
+	primary := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.FullName == "Service.Primary" })
+	t.Equal("Primary", primary.Name)
+	t.Equal("Save", primary.Methods[0].Name)
 
-	if _, err := goMod.WriteString(`module github.com/adamconnelly/kelpie-test
+	replica := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.FullName == "Service.Replica" })
+	t.Equal("Replica", replica.Name)
+	t.Equal("Save", replica.Methods[0].Name)
+}
 
-go 1.22.1`); err != nil {
-		return nil, nil, errors.Wrap(err, "could not write go.mod file")
+func (t *ParserTests) Test_Parse_SupportsSelfReferencingStructs() {
+	// Arrange
+	input := `package tree
+
+type Node struct {
+	Parent *Node
+	Children []*Node
+	Visitor interface {
+		Visit(node *Node)
 	}
+}`
 
-	testFile, err := os.Create(filepath.Join(packageDir, "test.go"))
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not create temp file for source")
+	// Act
+	result, _, err := t.ParseInput("tree", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Equal("Node.Visitor", result.Mocks[0].FullName)
+}
+
+func (t *ParserTests) Test_Parse_CanParseStdlibInterfaces() {
+	// Arrange
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Reader").Return(true))
+
+	// Act
+	result, err := parser.Parse("io", ".", t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Len(result.Mocks[0].Methods, 1)
+	t.Equal("Read", result.Mocks[0].Methods[0].Name)
+}
+
+func (t *ParserTests) Test_Parse_SupportsChannels() {
+	// Arrange
+	input := `package events
+
+import "net/http"
+
+type Event struct{}
+
+type EventBus interface {
+	Subscribe(topic string) (<-chan Event, error)
+	Publish(events chan<- Event)
+	Requests(requests chan *http.Request) chan *http.Request
+	Nested() chan (<-chan Event)
+}`
+
+	// Act
+	result, _, err := t.ParseInput("events", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	eventBus := result.Mocks[0]
+
+	subscribe := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Subscribe" })
+	t.Equal("<-chan events.Event", subscribe.Results[0].Type)
+	t.Equal("events.Event", subscribe.Results[0].ChannelElementType)
+	t.Equal("", subscribe.Results[1].ChannelElementType)
+	t.Equal("events.Event", subscribe.ChannelElementType())
+
+	publish := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Publish" })
+	t.Equal("chan<- events.Event", publish.Parameters[0].Type)
+
+	requests := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Requests" })
+	t.Equal("chan *http.Request", requests.Parameters[0].Type)
+	t.Equal("chan *http.Request", requests.Results[0].Type)
+	t.Equal("*http.Request", requests.ChannelElementType())
+
+	nested := slices.FirstOrPanic(eventBus.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Nested" })
+	t.Equal("chan (<-chan events.Event)", nested.Results[0].Type)
+	t.Equal("<-chan events.Event", nested.ChannelElementType())
+
+	t.Len(eventBus.Imports, 2)
+	t.Contains(eventBus.Imports, `"net/http"`)
+	t.Contains(eventBus.Imports, `"github.com/adamconnelly/kelpie-test/events"`)
+}
+
+func (t *ParserTests) Test_MethodDefinition_ChannelElementType_ReturnsEmptyIfMethodDoesNotReturnASingleChannel() {
+	// Arrange
+	method := parser.MethodDefinition{
+		Results: []parser.ResultDefinition{
+			{Type: "<-chan int", ChannelElementType: "int"},
+			{Type: "chan string", ChannelElementType: "string"},
+		},
 	}
 
-	if _, err := testFile.WriteString(input); err != nil {
-		return nil, nil, errors.Wrap(err, "could not write test case to file")
+	// Act
+	elementType := method.ChannelElementType()
+
+	// Assert
+	t.Empty(elementType)
+}
+
+func (t *ParserTests) Test_Parse_SupportsArrays() {
+	// Arrange
+	input := `package hashing
+
+const size = 4
+
+type Hasher interface {
+	Hash(data []byte) [32]byte
+	Split(values [size]int) [2][size * 2]string
+}`
+
+	// Act
+	result, _, err := t.ParseInput("hashing", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	hasher := result.Mocks[0]
+
+	hash := slices.FirstOrPanic(hasher.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Hash" })
+	t.Equal("[]byte", hash.Parameters[0].Type)
+	t.Equal("[32]byte", hash.Results[0].Type)
+
+	split := slices.FirstOrPanic(hasher.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Split" })
+	t.Equal("[4]int", split.Parameters[0].Type)
+	t.Equal("[2][8]string", split.Results[0].Type)
+
+	t.Empty(hasher.Imports)
+}
+
+func (t *ParserTests) Test_Parse_SupportsAliasesToInterfaces() {
+	// Arrange
+	input := `package storage
+
+type Backend interface {
+	// Get gets a value.
+	Get(key string) (string, error)
+}
+
+type Store = Backend`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Store").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	store := result.Mocks[0]
+	t.Equal("Store", store.Name)
+	t.Equal("Store", store.FullName)
+	t.Equal("store", store.PackageName)
+	t.Len(store.Methods, 1)
+	t.Equal("Get", store.Methods[0].Name)
+	t.Equal("Get gets a value.", store.Methods[0].Comment)
+	t.Equal("key", store.Methods[0].Parameters[0].Name)
+	t.Equal("string", store.Methods[0].Parameters[0].Type)
+}
+
+func (t *ParserTests) Test_Parse_SupportsAliasesToImportedInterfaces() {
+	// Arrange
+	input := `package storage
+
+import "io"
+
+type Stream = io.ReadCloser`
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	stream := result.Mocks[0]
+	t.Equal("Stream", stream.Name)
+	t.Len(stream.Methods, 2)
+	t.True(slices.Contains(stream.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Read" }))
+	t.True(slices.Contains(stream.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Close" }))
+	t.Empty(stream.Imports)
+}
+
+func (t *ParserTests) Test_Parse_SupportsAliasesToGenericInterfaceInstantiations() {
+	// Arrange
+	input := `package storage
+
+type User struct{}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+}
+
+type UserCache = Cache[string, User]`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("UserCache").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	userCache := result.Mocks[0]
+	t.Equal("UserCache", userCache.Name)
+	t.Empty(userCache.TypeParameters)
+
+	get := userCache.Methods[0]
+	t.Equal("string", get.Parameters[0].Type)
+	t.Equal("storage.User", get.Results[0].Type)
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/storage"`}, userCache.Imports)
+}
+
+func (t *ParserTests) Test_Parse_SupportsAliasesToInterfaceLiterals() {
+	// Arrange
+	input := `package handlers
+
+type Handler = interface {
+	Handle(request string) error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("handlers", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Equal("Handler", result.Mocks[0].Name)
+	t.Equal("Handle", result.Mocks[0].Methods[0].Name)
+}
+
+func (t *ParserTests) Test_Parse_IgnoresAliasesToNonInterfaceTypes() {
+	// Arrange
+	input := `package users
+
+type ID = int`
+
+	// Act
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Empty(result.Mocks)
+}
+
+func (t *ParserTests) Test_Parse_SupportsAnonymousStructs() {
+	// Arrange
+	input := `package users
+
+import "io"
+
+type User struct{}
+
+type UserService interface {
+	Update(update struct {
+		ID, Version int
+		Name string ` + "`json:\"name\"`" + `
+		io.Reader
+	}) struct{ User *User }
+	Empty() struct{}
+}`
+
+	// Act
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	userService := result.Mocks[0]
+
+	update := slices.FirstOrPanic(userService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Update" })
+	t.Equal("struct{ID int; Version int; Name string \"json:\\\"name\\\"\"; io.Reader}", update.Parameters[0].Type)
+	t.Equal("struct{User *users.User}", update.Results[0].Type)
+
+	empty := slices.FirstOrPanic(userService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Empty" })
+	t.Equal("struct{}", empty.Results[0].Type)
+
+	t.Len(userService.Imports, 2)
+	t.Contains(userService.Imports, `"io"`)
+	t.Contains(userService.Imports, `"github.com/adamconnelly/kelpie-test/users"`)
+}
+
+func (t *ParserTests) Test_Parse_SupportsParenthesizedTypes() {
+	// Arrange
+	input := `package users
+
+type User struct{}
+
+type UserService interface {
+	FindUser(id (int)) (*(User), error)
+}`
+
+	// Act
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	findUser := result.Mocks[0].Methods[0]
+	t.Equal("int", findUser.Parameters[0].Type)
+	t.Equal("*users.User", findUser.Results[0].Type)
+}
+
+func (t *ParserTests) Test_Parse_SupportsGenericTypes() {
+	// Arrange
+	input := `package users
+
+import "sync/atomic"
+
+type User struct{}
+
+type Page[T any] struct {
+	Items []T
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type UserService interface {
+	ListUsers(page int) (Page[User], error)
+	Current() *atomic.Pointer[User]
+	Lookup(pairs ...Pair[string, *User]) map[string]Page[Pair[int, User]]
+	Each(callback func(users ...User))
+}`
+
+	// Act
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	userService := result.Mocks[0]
+
+	listUsers := slices.FirstOrPanic(userService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "ListUsers" })
+	t.Equal("users.Page[users.User]", listUsers.Results[0].Type)
+
+	current := slices.FirstOrPanic(userService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Current" })
+	t.Equal("*atomic.Pointer[users.User]", current.Results[0].Type)
+
+	lookup := slices.FirstOrPanic(userService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Lookup" })
+	t.Equal("users.Pair[string, *users.User]", lookup.Parameters[0].Type)
+	t.True(lookup.Parameters[0].IsVariadic)
+	t.Equal("map[string]users.Page[users.Pair[int, users.User]]", lookup.Results[0].Type)
+
+	each := slices.FirstOrPanic(userService.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Each" })
+	t.Equal("func(users ...users.User)", each.Parameters[0].Type)
+
+	t.Len(userService.Imports, 2)
+	t.Contains(userService.Imports, `"sync/atomic"`)
+	t.Contains(userService.Imports, `"github.com/adamconnelly/kelpie-test/users"`)
+}
+
+func (t *ParserTests) Test_Parse_SupportsEmbeddedInterfaces() {
+	// Arrange
+	input := `package storage
+
+type Item struct{}
+
+type Reader interface {
+	// Read reads the item with the specified ID.
+	Read(id string) (*Item, error)
+}
+
+type Writer interface {
+	Write(item *Item) error
+}
+
+type Store interface {
+	Reader
+	Writer
+
+	Close() error
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Store").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	store := result.Mocks[0]
+	t.Len(store.Methods, 3)
+
+	read := slices.FirstOrPanic(store.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Read" })
+	t.Equal("Read reads the item with the specified ID.", read.Comment)
+	t.Len(read.Parameters, 1)
+	t.Equal("id", read.Parameters[0].Name)
+	t.Equal("string", read.Parameters[0].Type)
+	t.Len(read.Results, 2)
+	t.Equal("*storage.Item", read.Results[0].Type)
+	t.Equal("error", read.Results[1].Type)
+
+	write := slices.FirstOrPanic(store.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Write" })
+	t.Len(write.Parameters, 1)
+	t.Equal("item", write.Parameters[0].Name)
+	t.Equal("*storage.Item", write.Parameters[0].Type)
+
+	closeMethod := slices.FirstOrPanic(store.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Close" })
+	t.Len(closeMethod.Results, 1)
+
+	t.Len(store.Imports, 1)
+	t.Contains(store.Imports, `"github.com/adamconnelly/kelpie-test/storage"`)
+}
+
+func (t *ParserTests) Test_Parse_SupportsEmbeddedInterfacesFromOtherPackages() {
+	// Arrange
+	input := `package storage
+
+import (
+	"io"
+	stdio "io"
+)
+
+type ReadCloser interface {
+	io.Reader
+	stdio.WriterTo
+
+	Close() error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	readCloser := result.Mocks[0]
+	t.Len(readCloser.Methods, 3)
+
+	read := slices.FirstOrPanic(readCloser.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Read" })
+	t.Len(read.Parameters, 1)
+	t.Equal("p", read.Parameters[0].Name)
+	t.Equal("[]byte", read.Parameters[0].Type)
+	t.Len(read.Results, 2)
+	t.Equal("n", read.Results[0].Name)
+	t.Equal("int", read.Results[0].Type)
+	t.Equal("err", read.Results[1].Name)
+	t.Equal("error", read.Results[1].Type)
+
+	writeTo := slices.FirstOrPanic(readCloser.Methods, func(m parser.MethodDefinition) bool { return m.Name == "WriteTo" })
+	t.Len(writeTo.Parameters, 1)
+	t.Equal("stdio.Writer", writeTo.Parameters[0].Type)
+	t.True(writeTo.Parameters[0].IsNonEmptyInterface)
+
+	t.Len(readCloser.Imports, 1)
+	t.Contains(readCloser.Imports, `stdio "io"`)
+}
+
+func (t *ParserTests) Test_Parse_AddsImportsForPackagesOnlyReferencedByEmbeddedInterfaces() {
+	// Arrange
+	input := `package cookies
+
+import "net/http"
+
+type CookieStore interface {
+	http.CookieJar
+}`
+
+	// Act
+	result, _, err := t.ParseInput("cookies", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	cookieStore := result.Mocks[0]
+	setCookies := slices.FirstOrPanic(cookieStore.Methods, func(m parser.MethodDefinition) bool { return m.Name == "SetCookies" })
+	t.Equal("*url.URL", setCookies.Parameters[0].Type)
+	t.Equal("[]*http.Cookie", setCookies.Parameters[1].Type)
+
+	t.Len(cookieStore.Imports, 2)
+	t.Contains(cookieStore.Imports, `"net/http"`)
+	t.Contains(cookieStore.Imports, `"net/url"`)
+}
+
+func (t *ParserTests) Test_Parse_SupportsMultipleLevelsOfEmbeddedInterfaces() {
+	// Arrange
+	input := `package storage
+
+type Closer interface {
+	Close() error
+}
+
+type Reader interface {
+	Closer
+	Read() ([]byte, error)
+}
+
+type Writer interface {
+	Closer
+	Write(data []byte) error
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type Store interface {
+	ReadWriter
+	Closer
+
+	Flush() error
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Store").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	store := result.Mocks[0]
+	methodNames := slices.Map(store.Methods, func(m parser.MethodDefinition) string { return m.Name })
+	t.ElementsMatch([]string{"Close", "Read", "Write", "Flush"}, methodNames)
+	t.Empty(store.Imports)
+}
+
+func (t *ParserTests) Test_Parse_SupportsGenericInterfaces() {
+	// Arrange
+	input := `package storage
+
+type Number interface {
+	~int | ~float64
+}
+
+type Repository[T any, ID comparable, K ~string, N Number] interface {
+	Get(id ID) (T, error)
+	Find(key K, ids ...ID) ([]T, error)
+	Total(name string) N
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Repository").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	repository := result.Mocks[0]
+	t.Equal([]parser.TypeParameterDefinition{
+		{Name: "T", Constraint: "any"},
+		{Name: "ID", Constraint: "comparable"},
+		{Name: "K", Constraint: "~string"},
+		{Name: "N", Constraint: "storage.Number"},
+	}, repository.TypeParameters)
+
+	get := slices.FirstOrPanic(repository.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Get" })
+	t.Equal("ID", get.Parameters[0].Type)
+	t.True(get.Parameters[0].IsTypeParameter)
+	t.Equal("T", get.Results[0].Type)
+
+	find := slices.FirstOrPanic(repository.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Find" })
+	t.Equal("K", find.Parameters[0].Type)
+	t.True(find.Parameters[0].IsTypeParameter)
+	t.Equal("ID", find.Parameters[1].Type)
+	t.True(find.Parameters[1].IsVariadic)
+	t.True(find.Parameters[1].IsTypeParameter)
+	t.Equal("[]T", find.Results[0].Type)
+
+	total := slices.FirstOrPanic(repository.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Total" })
+	t.Equal("string", total.Parameters[0].Type)
+	t.False(total.Parameters[0].IsTypeParameter)
+	t.Equal("N", total.Results[0].Type)
+
+	// The only import required is for the constraint - type parameters don't need importing.
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/storage"`}, repository.Imports)
+}
+
+func (t *ParserTests) Test_Parse_SupportsFunctionTypes() {
+	// Arrange
+	input := `package auth
+
+import "context"
+
+type Authorizer func(ctx context.Context, user string) error
+
+type Clock func() int64
+
+type hasher func(value string) string`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Authorizer").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("auth", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	authorizer := result.Mocks[0]
+	t.Equal("Authorizer", authorizer.Name)
+	t.Equal("Authorizer", authorizer.FullName)
+	t.Equal("authorizer", authorizer.PackageName)
+	t.Equal("auth.Authorizer", authorizer.FunctionType)
+	t.Equal([]string{`"context"`, `"github.com/adamconnelly/kelpie-test/auth"`}, authorizer.Imports)
+
+	t.Len(authorizer.Methods, 1)
+	call := authorizer.Methods[0]
+	t.Equal("Call", call.Name)
+	t.Equal([]parser.ParameterDefinition{
+		{Name: "ctx", Type: "context.Context", IsNonEmptyInterface: true},
+		{Name: "user", Type: "string"},
+	}, call.Parameters)
+	t.Equal([]parser.ResultDefinition{{Type: "error"}}, call.Results)
+}
+
+func (t *ParserTests) Test_Parse_SupportsGenericFunctionTypes() {
+	// Arrange
+	input := `package validation
+
+type Validator[T any] func(value T) []string`
+
+	// Act
+	result, _, err := t.ParseInput("validation", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+
+	validator := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "Validator" })
+	t.Equal("validation.Validator[T]", validator.FunctionType)
+	t.Equal([]parser.TypeParameterDefinition{{Name: "T", Constraint: "any"}}, validator.TypeParameters)
+	t.Equal("T", validator.Methods[0].Parameters[0].Type)
+	t.True(validator.Methods[0].Parameters[0].IsTypeParameter)
+}
+
+func (t *ParserTests) Test_Parse_ReturnsNoFunctionTypeForInterfaces() {
+	// Arrange
+	input := `package maths
+
+type Maths interface {
+	Add(a, b int) int
+}`
+
+	// Act
+	result, _, err := t.ParseInput("maths", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Empty(result.Mocks[0].FunctionType)
+}
+
+func (t *ParserTests) Test_Parse_SupportsStructMethodSets() {
+	// Arrange
+	input := `package billing
+
+import (
+	"context"
+	"io"
+)
+
+type Logger struct{}
+
+// Log writes a log message.
+func (l *Logger) Log(message string) {}
+
+type Client struct {
+	*Logger
+	io.Closer
+
+	baseURL string
+}
+
+// Charge takes a payment.
+func (c *Client) Charge(ctx context.Context, amount int) error {
+	return nil
+}
+
+func (c Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) newRequest() {}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Client").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("billing", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	client := result.Mocks[0]
+	t.Equal("Client", client.Name)
+	t.Equal("client", client.PackageName)
+	t.Equal("*billing.Client", client.StructType)
+	t.Empty(client.FunctionType)
+	t.Equal([]string{`"context"`, `"github.com/adamconnelly/kelpie-test/billing"`}, client.Imports)
+
+	t.Equal([]string{"BaseURL", "Charge", "Close", "Log"}, slices.Map(client.Methods, func(m parser.MethodDefinition) string { return m.Name }))
+
+	charge := slices.FirstOrPanic(client.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Charge" })
+	t.Equal("Charge takes a payment.", charge.Comment)
+	t.Equal("context.Context", charge.Parameters[0].Type)
+	t.Equal("int", charge.Parameters[1].Type)
+	t.Equal("error", charge.Results[0].Type)
+
+	log := slices.FirstOrPanic(client.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Log" })
+	t.Equal("Log writes a log message.", log.Comment)
+}
+
+func (t *ParserTests) Test_Parse_SupportsGenericStructMethodSets() {
+	// Arrange
+	input := `package storage
+
+type Store[T any, ID comparable] struct{}
+
+func (s *Store[Item, Key]) Get(id Key) (Item, error) {
+	var item Item
+	return item, nil
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+	t.interfaceFilter.Setup(interfacefilter.Include("Store").Return(true))
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+
+	store := result.Mocks[0]
+	t.Equal("*storage.Store[T, ID]", store.StructType)
+	t.Equal([]parser.TypeParameterDefinition{{Name: "T", Constraint: "any"}, {Name: "ID", Constraint: "comparable"}}, store.TypeParameters)
+
+	get := store.Methods[0]
+	t.Equal("ID", get.Parameters[0].Type)
+	t.True(get.Parameters[0].IsTypeParameter)
+	t.Equal("T", get.Results[0].Type)
+}
+
+func (t *ParserTests) Test_Parse_DoesNotMockStructsThatAreNotIncluded() {
+	// Arrange
+	input := `package billing
+
+type Client struct{}
+
+func (c *Client) Charge(amount int) error {
+	return nil
+}`
+
+	t.interfaceFilter.Setup(interfacefilter.Include(kelpie.Any[string]()).Return(false))
+
+	// Act
+	result, _, err := t.ParseInput("billing", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Empty(result.Mocks)
+}
+
+func (t *ParserTests) Test_Parse_PatternsOnlyMatchInterfaces() {
+	// Arrange
+	input := `package billing
+
+type Client struct{}
+
+func (c *Client) Charge(amount int) error {
+	return nil
+}
+
+type Authorizer func(user string) bool
+
+type PaymentService interface {
+	Pay(amount int) error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("billing", input, parser.NewGlobInterfaceFilter("*"))
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Equal("PaymentService", result.Mocks[0].Name)
+}
+
+func (t *ParserTests) Test_Parse_DoesNotMockStructsWithoutExportedMethods() {
+	// Arrange
+	input := `package users
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func (u *User) validate() error {
+	return nil
+}`
+
+	// Act
+	result, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Empty(result.Mocks)
+}
+
+func (t *ParserTests) Test_Parse_IgnoresUnexportedInterfaces() {
+	// Arrange
+	input := `package reminders
+
+type reminderStore interface {
+	save(message string) error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("reminders", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Empty(result.Mocks)
+}
+
+func (t *ParserTests) Test_Parse_InPackage_IncludesUnexportedInterfaces() {
+	// Arrange
+	input := `package reminders
+
+import "time"
+
+type reminder struct {
+	message string
+	due     time.Time
+}
+
+type reminderStore interface {
+	save(r reminder) error
+	Due() []*reminder
+}`
+
+	// Act
+	result, _, err := t.ParseInput("reminders", input, t.interfaceFilter.Instance(), parser.InPackage())
+
+	// Assert
+	t.NoError(err)
+	t.Equal("reminders", result.PackageName)
+
+	store := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "reminderStore" })
+	t.Equal("reminderstore", store.PackageName)
+
+	save := slices.FirstOrPanic(store.Methods, func(m parser.MethodDefinition) bool { return m.Name == "save" })
+	t.Equal("reminder", save.Parameters[0].Type)
+
+	due := slices.FirstOrPanic(store.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Due" })
+	t.Equal("[]*reminder", due.Results[0].Type)
+
+	// Types from the package itself don't need to be imported.
+	t.Empty(store.Imports)
+}
+
+func (t *ParserTests) Test_Parse_InPackage_DoesNotQualifyLocalTypes() {
+	// Arrange
+	input := `package billing
+
+import "context"
+
+type Charge struct {
+	ID string
+}
+
+type Client struct{}
+
+func (c *Client) Charge(ctx context.Context, amount int) (*Charge, error) {
+	return nil, nil
+}
+
+type Authorizer func(ctx context.Context, charge Charge) error`
+
+	// Act
+	result, _, err := t.ParseInput("billing", input, t.interfaceFilter.Instance(), parser.InPackage())
+
+	// Assert
+	t.NoError(err)
+
+	client := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "Client" })
+	t.Equal("*Client", client.StructType)
+	t.Equal("*Charge", client.Methods[0].Results[0].Type)
+	t.Equal([]string{`"context"`}, client.Imports)
+
+	authorizer := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "Authorizer" })
+	t.Equal("Authorizer", authorizer.FunctionType)
+	t.Equal("Charge", authorizer.Methods[0].Parameters[1].Type)
+	t.Equal([]string{`"context"`}, authorizer.Imports)
+}
+
+func (t *ParserTests) Test_Parse_Annotated_IncludesAnnotatedTypes() {
+	// Arrange
+	input := `package notifications
+
+// Notifier sends notifications.
+//
+//kelpie:mock
+type Notifier interface {
+	Notify(message string) error
+}
+
+type (
+	//kelpie:mock package=alertmock inPackage
+	Alerter interface {
+		Alert(severity int)
+	}
+
+	Unannotated interface {
+		DoSomething()
+	}
+)
+
+//kelpie:mocked
+type NotAnAnnotation interface {
+	DoSomethingElse()
+}`
+
+	// Act
+	result, _, err := t.ParseInput("notifications", input, &parser.IncludingInterfaceFilter{}, parser.Annotated())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 2)
+
+	notifier := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.Name == "Notifier" })
+	t.NotNil(notifier.Annotation)
+	t.Empty(notifier.Annotation.Options)
+
+	alerter := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.Name == "Alerter" })
+	t.NotNil(alerter.Annotation)
+	t.Equal(map[string]string{"package": "alertmock", "inPackage": "true"}, alerter.Annotation.Options)
+}
+
+func (t *ParserTests) Test_Parse_OnlyIncludesAnnotatedTypesWhenRequested() {
+	// Arrange
+	input := `package notifications
+
+//kelpie:mock
+type Notifier interface {
+	Notify(message string) error
+}`
+
+	// Act
+	result, _, err := t.ParseInput("notifications", input, &parser.IncludingInterfaceFilter{})
+
+	// Assert
+	t.NoError(err)
+	t.Empty(result.Mocks)
+}
+
+func (t *ParserTests) Test_Parse_SetsAnnotationForTypesIncludedByFilter() {
+	// Arrange
+	input := `package notifications
+
+//kelpie:mock package=notifiermock
+type Notifier interface {
+	Notify(message string) error
+}
+
+type Alerter interface {
+	Alert(severity int)
+}`
+
+	// Act
+	result, _, err := t.ParseInput("notifications", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 2)
+
+	notifier := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.Name == "Notifier" })
+	t.Equal(map[string]string{"package": "notifiermock"}, notifier.Annotation.Options)
+
+	alerter := slices.FirstOrPanic(result.Mocks, func(i parser.MockedInterface) bool { return i.Name == "Alerter" })
+	t.Nil(alerter.Annotation)
+}
+
+func (t *ParserTests) Test_Load_ParsesMultiplePackages() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"accounts/accounts.go": `package accounts
+
+type AccountService interface {
+	GetAccount(id int) string
+}`,
+		// The accounts tests import users, which imports accounts, so Go creates a variant of
+		// users for the accounts tests, as well as the variant including users' own tests.
+		"accounts/accounts_test.go": `package accounts_test
+
+import _ "github.com/adamconnelly/kelpie-test/users"`,
+		"users/users.go": `package users
+
+import "github.com/adamconnelly/kelpie-test/accounts"
+
+type UserService interface {
+	GetUserAccount(id int) accounts.AccountService
+}`,
+		"users/users_test.go": `package users
+
+type UserCache interface {
+	Get(id int) string
+}`,
+	})
+
+	// Act
+	loaded, err := parser.Load(moduleDir, "github.com/adamconnelly/kelpie-test/users", "github.com/adamconnelly/kelpie-test/accounts")
+	t.Require().NoError(err)
+
+	users, usersErr := loaded.Parse("github.com/adamconnelly/kelpie-test/users", t.interfaceFilter.Instance())
+	accounts, accountsErr := loaded.Parse("github.com/adamconnelly/kelpie-test/accounts", t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(usersErr)
+	t.Equal("users", users.PackageName)
+	t.Equal([]string{"UserService", "UserCache"}, slices.Map(users.Mocks, func(m parser.MockedInterface) string { return m.Name }))
+
+	t.NoError(accountsErr)
+	t.Equal("accounts", accounts.PackageName)
+	t.Equal([]string{"AccountService"}, slices.Map(accounts.Mocks, func(m parser.MockedInterface) string { return m.Name }))
+}
+
+func (t *ParserTests) Test_Load_ReturnsErrorWhenParsingPackageThatWasNotLoaded() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go": `package users`,
+	})
+
+	loaded, err := parser.Load(moduleDir, "github.com/adamconnelly/kelpie-test/users")
+	t.Require().NoError(err)
+
+	// Act
+	_, err = loaded.Parse("github.com/adamconnelly/kelpie-test/accounts", t.interfaceFilter.Instance())
+
+	// Assert
+	t.ErrorContains(err, "could not find the 'test' variant of package 'github.com/adamconnelly/kelpie-test/accounts'")
+}
+
+func (t *ParserTests) Test_Load_SupportsRelativePackagePaths() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go": `package users
+
+type UserService interface {
+	GetUser(id int) string
+}`,
+		"users/users_test.go": `package users
+
+type UserCache interface {
+	Get(id int) string
+}`,
+	})
+
+	// Act
+	loaded, err := parser.Load(filepath.Join(moduleDir, "users"), ".")
+	t.Require().NoError(err)
+
+	result, err := loaded.Parse(".", t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Equal("users", result.PackageName)
+	t.Equal([]string{"UserService", "UserCache"}, slices.Map(result.Mocks, func(m parser.MockedInterface) string { return m.Name }))
+}
+
+func (t *ParserTests) Test_Load_ReturnsErrorWhenRelativePathMatchesMultiplePackages() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"users/users.go":       `package users`,
+		"accounts/accounts.go": `package accounts`,
+	})
+
+	// Act
+	_, err := parser.Load(moduleDir, "./...")
+
+	// Assert
+	t.ErrorContains(err, "the package './...' must match exactly one package, but it matched 2")
+}
+
+func (t *ParserTests) Test_Combine_AliasesPackagesWithTheSameNameUsingSharedImports() {
+	// Arrange
+	moduleDir := testmodule.Write(t.T(), map[string]string{
+		"api/errors/errors.go": `package errors
+
+type Error struct{}`,
+		"db/errors/errors.go": `package errors
+
+type Error struct{}`,
+		"users/users.go": `package users
+
+import "github.com/adamconnelly/kelpie-test/api/errors"
+
+type UserService interface {
+	GetUser(id int) *errors.Error
+}`,
+		"accounts/accounts.go": `package accounts
+
+import "github.com/adamconnelly/kelpie-test/db/errors"
+
+type AccountService interface {
+	GetAccount(id int) *errors.Error
+}`,
+	})
+
+	loaded, err := parser.Load(moduleDir, "github.com/adamconnelly/kelpie-test/users", "github.com/adamconnelly/kelpie-test/accounts")
+	t.Require().NoError(err)
+
+	imports := parser.NewImportSet()
+	users, err := loaded.Parse("github.com/adamconnelly/kelpie-test/users", t.interfaceFilter.Instance(), parser.SharedImports(imports))
+	t.Require().NoError(err)
+	accounts, err := loaded.Parse("github.com/adamconnelly/kelpie-test/accounts", t.interfaceFilter.Instance(), parser.SharedImports(imports))
+	t.Require().NoError(err)
+
+	// Act
+	combined, err := parser.Combine("UserAccountService", users.Mocks[0], accounts.Mocks[0])
+
+	// Assert
+	t.NoError(err)
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/api/errors"`, `dberrors "github.com/adamconnelly/kelpie-test/db/errors"`}, combined.Imports)
+	t.Equal("*errors.Error", combined.Methods[0].Results[0].Type)
+	t.Equal("*dberrors.Error", combined.Methods[1].Results[0].Type)
+}
+
+func (t *ParserTests) Test_FindTypeAfterLine_ReturnsTypeBelowLine() {
+	// Arrange
+	filename := t.WriteFile(`package notifications
+
+type Alerter interface {
+	Alert(severity int)
+}
+
+//go:generate kelpie generate
+// Notifier sends notifications.
+type Notifier interface {
+	Notify(message string) error
+}`)
+
+	// Act
+	name, err := parser.FindTypeAfterLine(filename, 7)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("Notifier", name)
+}
+
+func (t *ParserTests) Test_FindTypeAfterLine_ReturnsTypeDirectlyBelowLineWithoutDocComment() {
+	// Arrange
+	filename := t.WriteFile(`package notifications
+
+// Notifier sends notifications.
+//go:generate kelpie generate
+type Notifier interface {
+	Notify(message string) error
+}`)
+
+	// Act
+	name, err := parser.FindTypeAfterLine(filename, 4)
+
+	// Assert
+	t.NoError(err)
+	t.Equal("Notifier", name)
+}
+
+func (t *ParserTests) Test_FindTypeAfterLine_ReturnsErrorIfTypeIsNotDirectlyBelowLine() {
+	// Arrange
+	filename := t.WriteFile(`package notifications
+
+//go:generate kelpie generate
+
+// Unrelated comments about the package.
+
+// Notifier sends notifications.
+type Notifier interface {
+	Notify(message string) error
+}`)
+
+	// Act
+	_, err := parser.FindTypeAfterLine(filename, 3)
+
+	// Assert
+	t.ErrorContains(err, "the type declared after line 3")
+	t.ErrorContains(err, "must be directly below it")
+}
+
+func (t *ParserTests) Test_FindTypeAfterLine_ReturnsErrorIfNextDeclarationIsNotAType() {
+	// Arrange
+	filename := t.WriteFile(`package notifications
+
+//go:generate kelpie generate
+func Notify() {}
+
+type Notifier interface {
+	Notify(message string) error
+}`)
+
+	// Act
+	_, err := parser.FindTypeAfterLine(filename, 3)
+
+	// Assert
+	t.ErrorContains(err, "could not find a type declared after line 3")
+}
+
+func (t *ParserTests) Test_FindTypeAfterLine_ReturnsErrorIfDeclarationContainsMultipleTypes() {
+	// Arrange
+	filename := t.WriteFile(`package notifications
+
+//go:generate kelpie generate
+type (
+	Notifier interface {
+		Notify(message string) error
+	}
+
+	Alerter interface {
+		Alert(severity int)
+	}
+)`)
+
+	// Act
+	_, err := parser.FindTypeAfterLine(filename, 3)
+
+	// Assert
+	t.ErrorContains(err, "contains 2 types, but it must contain exactly one")
+}
+
+func (t *ParserTests) Test_Parse_IncludesInterfacesFromTestFilesByDefault() {
+	// Arrange
+	files := map[string]string{
+		"users.go": `package users
+
+type UserService interface {
+	GetUser(id int) string
+}`,
+		"users_test.go": `package users
+
+type UserCache interface {
+	Get(id int) string
+}`,
+	}
+
+	// Act
+	result, packageDir, err := t.ParseFiles("users", files, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Equal(*packageDir, result.PackageDirectory)
+	t.Equal("users", result.PackageName)
+	t.Len(result.Mocks, 2)
+
+	userService := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "UserService" })
+	t.False(userService.IsTestOnly)
+
+	userCache := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.Name == "UserCache" })
+	t.True(userCache.IsTestOnly)
+}
+
+func (t *ParserTests) Test_Parse_PackageVariantExcludesTestFiles() {
+	// Arrange
+	files := map[string]string{
+		"users.go": `package users
+
+type UserService interface {
+	GetUser(id int) string
+}`,
+		"users_test.go": `package users
+
+type UserCache interface {
+	Get(id int) string
+}`,
+	}
+
+	// Act
+	result, _, err := t.ParseFiles("users", files, t.interfaceFilter.Instance(), parser.Variant(parser.PackageVariantPackage))
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Equal("UserService", result.Mocks[0].Name)
+}
+
+func (t *ParserTests) Test_Parse_ExternalTestVariantParsesTheExternalTestPackage() {
+	// Arrange
+	files := map[string]string{
+		"users.go": `package users
+
+type User struct {
+	ID int
+}
+
+type UserService interface {
+	GetUser(id int) User
+}`,
+		"users_test.go": `package users_test
+
+import "github.com/adamconnelly/kelpie-test/users"
+
+type userCache interface {
+	Get(id int) users.User
+	Add(user users.User, options cacheOptions)
+}
+
+type cacheOptions struct {
+	ttl int
+}`,
+	}
+
+	// Act
+	result, packageDir, err := t.ParseFiles("users", files, t.interfaceFilter.Instance(), parser.Variant(parser.PackageVariantExternalTest), parser.InPackage())
+
+	// Assert
+	t.NoError(err)
+	t.Equal(*packageDir, result.PackageDirectory)
+	t.Equal("users_test", result.PackageName)
+	t.Len(result.Mocks, 1)
+
+	userCache := result.Mocks[0]
+	t.Equal("userCache", userCache.Name)
+	t.True(userCache.IsTestOnly)
+
+	// Types from the package being tested still need importing, but types from the test package don't.
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/users"`}, userCache.Imports)
+	add := slices.FirstOrPanic(userCache.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Add" })
+	t.Equal("users.User", add.Parameters[0].Type)
+	t.Equal("cacheOptions", add.Parameters[1].Type)
+}
+
+func (t *ParserTests) Test_Parse_ReturnsErrorIfVariantDoesNotExist() {
+	// Arrange
+	input := `package users
+
+type UserService interface {
+	GetUser(id int) string
+}`
+
+	// Act
+	_, _, err := t.ParseInput("users", input, t.interfaceFilter.Instance(), parser.Variant(parser.PackageVariantExternalTest))
+
+	// Assert
+	t.ErrorContains(err, "could not find the 'xtest' variant of package 'github.com/adamconnelly/kelpie-test/users'")
+}
+
+func (t *ParserTests) Test_Parse_ReturnsNoTypeParametersForNonGenericInterfaces() {
+	// Arrange
+	input := `package storage
+
+type Repository interface {
+	Get(id int) (string, error)
+}`
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, t.interfaceFilter.Instance())
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 1)
+	t.Empty(result.Mocks[0].TypeParameters)
+}
+
+func (t *ParserTests) Test_Parse_SupportsInstantiationsOfGenericInterfaces() {
+	// Arrange
+	input := `package storage
+
+import "net/http"
+
+type Item struct{}
+
+type Cache[K comparable, V any] interface {
+	// Get gets the value stored against the key.
+	Get(key K) (V, bool)
+	Set(key K, value V)
+}`
+
+	filter := &parser.IncludingInterfaceFilter{
+		InterfacesToInclude: []string{"Cache[string, *http.Request]", "Cache[int, []Item]"},
+	}
+
+	// Act
+	result, _, err := t.ParseInput("storage", input, filter)
+
+	// Assert
+	t.NoError(err)
+	t.Len(result.Mocks, 2)
+
+	requestCache := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.FullName == "Cache[string, *http.Request]" })
+	t.Equal("Cache", requestCache.Name)
+	t.Equal("cache", requestCache.PackageName)
+	t.Empty(requestCache.TypeParameters)
+
+	get := slices.FirstOrPanic(requestCache.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Get" })
+	t.Equal("Get gets the value stored against the key.", get.Comment)
+	t.Equal("key", get.Parameters[0].Name)
+	t.Equal("string", get.Parameters[0].Type)
+	t.False(get.Parameters[0].IsTypeParameter)
+	t.Equal("*http.Request", get.Results[0].Type)
+	t.Equal("bool", get.Results[1].Type)
+
+	set := slices.FirstOrPanic(requestCache.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Set" })
+	t.Equal("string", set.Parameters[0].Type)
+	t.Equal("*http.Request", set.Parameters[1].Type)
+
+	t.Equal([]string{`"net/http"`}, requestCache.Imports)
+
+	itemCache := slices.FirstOrPanic(result.Mocks, func(m parser.MockedInterface) bool { return m.FullName == "Cache[int, []Item]" })
+	set = slices.FirstOrPanic(itemCache.Methods, func(m parser.MethodDefinition) bool { return m.Name == "Set" })
+	t.Equal("int", set.Parameters[0].Type)
+	t.Equal("[]storage.Item", set.Parameters[1].Type)
+
+	t.Equal([]string{`"github.com/adamconnelly/kelpie-test/storage"`}, itemCache.Imports)
+}
+
+func (t *ParserTests) Test_Parse_ReturnsErrorIfInstantiationCannotBeResolved() {
+	// Arrange
+	input := `package storage
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+}`
+
+	filter := &parser.IncludingInterfaceFilter{
+		InterfacesToInclude: []string{"Cache[string, users.User]"},
+	}
+
+	// Act
+	_, _, err := t.ParseInput("storage", input, filter)
+
+	// Assert
+	t.ErrorContains(err, "could not resolve generic interface instantiation 'Cache[string, users.User]'")
+}
+
+func (t *ParserTests) Test_IncludingInterfaceFilter_Instantiations_ReturnsMatchingInstantiations() {
+	// Arrange
+	filter := &parser.IncludingInterfaceFilter{
+		InterfacesToInclude: []string{"Cache", "Cache[string, int]", "CacheFactory", "Cache[int, int]"},
+	}
+
+	// Act
+	instantiations := filter.Instantiations("Cache")
+
+	// Assert
+	t.Equal([]string{"Cache[string, int]", "Cache[int, int]"}, instantiations)
+}
+
+func (t *ParserTests) Test_MockedInterface_AnyMethodsHaveParameters_ReturnsFalseIfNoMethodsHaveParameters() {
+	// Arrange
+	mockedInterface := parser.MockedInterface{
+		Methods: []parser.MethodDefinition{
+			{
+				Name: "MethodWithoutParameters",
+			},
+		},
+	}
+
+	// Act
+	hasParameters := mockedInterface.AnyMethodsHaveParameters()
+
+	// Assert
+	t.False(hasParameters)
+}
+
+func (t *ParserTests) Test_MockedInterface_AnyMethodsHaveParameters_ReturnsTrueIfAtLeastOneMethodHasParameters() {
+	// Arrange
+	mockedInterface := parser.MockedInterface{
+		Methods: []parser.MethodDefinition{
+			{
+				Name: "MethodWithoutParameters",
+			},
+			{
+				Name: "MethodWithParameters",
+				Parameters: []parser.ParameterDefinition{
+					{
+						Name: "value",
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	hasParameters := mockedInterface.AnyMethodsHaveParameters()
+
+	// Assert
+	t.True(hasParameters)
+}
+
+func (t *ParserTests) Test_MockedInterface_AnyMethodsHaveExactMatchParameters_ReturnsFalseIfAllParametersAreNonEmptyInterfaces() {
+	// Arrange
+	mockedInterface := parser.MockedInterface{
+		Methods: []parser.MethodDefinition{
+			{
+				Name: "MethodWithoutParameters",
+			},
+			{
+				Name: "MethodWithInterfaceParameters",
+				Parameters: []parser.ParameterDefinition{
+					{
+						Name:                "ctx",
+						IsNonEmptyInterface: true,
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	hasExactMatchParameters := mockedInterface.AnyMethodsHaveExactMatchParameters()
+
+	// Assert
+	t.False(hasExactMatchParameters)
+}
+
+func (t *ParserTests) Test_MockedInterface_AnyMethodsHaveExactMatchParameters_ReturnsTrueIfAnyParameterIsNotANonEmptyInterface() {
+	// Arrange
+	mockedInterface := parser.MockedInterface{
+		Methods: []parser.MethodDefinition{
+			{
+				Name: "MethodWithParameters",
+				Parameters: []parser.ParameterDefinition{
+					{
+						Name:                "ctx",
+						IsNonEmptyInterface: true,
+					},
+					{
+						Name: "value",
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	hasExactMatchParameters := mockedInterface.AnyMethodsHaveExactMatchParameters()
+
+	// Assert
+	t.True(hasExactMatchParameters)
+}
+
+func (t *ParserTests) Test_MethodDefinition_FixedParameters_ExcludesVariadicParameters() {
+	// Arrange
+	method := parser.MethodDefinition{
+		Name: "Printf",
+		Parameters: []parser.ParameterDefinition{
+			{Name: "format"},
+			{Name: "args", IsVariadic: true},
+		},
+	}
+
+	// Act
+	parameters := method.FixedParameters()
+
+	// Assert
+	t.Equal([]parser.ParameterDefinition{{Name: "format"}}, parameters)
+}
+
+// TODO: add a test for handling types that can't be resolved (e.g. because of a mistake in the code we're parsing)
+// TODO: what about empty interfaces? Return a warning?
+
+func (t *ParserTests) WriteFile(input string) string {
+	filename := filepath.Join(t.T().TempDir(), "test.go")
+	t.Require().NoError(os.WriteFile(filename, []byte(input), 0600))
+
+	return filename
+}
+
+func (t *ParserTests) ParseInput(packageName, input string, filter parser.InterfaceFilter, options ...parser.ParseOption) (*parser.ParsedPackage, *string, error) {
+	return t.ParseFiles(packageName, map[string]string{"test.go": input}, filter, options...)
+}
+
+func (t *ParserTests) ParseFiles(packageName string, files map[string]string, filter parser.InterfaceFilter, options ...parser.ParseOption) (*parser.ParsedPackage, *string, error) {
+	tmpDir, err := os.MkdirTemp("", "kelpie-parser-tests")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create temp dir for module")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	packageDir := filepath.Join(tmpDir, packageName)
+
+	if err := os.Mkdir(packageDir, os.ModePerm); err != nil {
+		return nil, nil, errors.Wrap(err, "could not create package directory")
+	}
+
+	goMod, err := os.Create(filepath.Join(tmpDir, "go.mod"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create file for go.mod")
+	}
+
+	if _, err := goMod.WriteString(`module github.com/adamconnelly/kelpie-test
+
+go 1.22.1`); err != nil {
+		return nil, nil, errors.Wrap(err, "could not write go.mod file")
+	}
+
+	for name, input := range files {
+		if err := os.WriteFile(filepath.Join(packageDir, name), []byte(input), 0600); err != nil {
+			return nil, nil, errors.Wrap(err, "could not write test case to file")
+		}
 	}
 
-	pkg, err := parser.Parse("github.com/adamconnelly/kelpie-test/"+packageName, tmpDir, filter)
+	pkg, err := parser.Parse("github.com/adamconnelly/kelpie-test/"+packageName, tmpDir, filter, options...)
 	if err != nil {
 		return pkg, nil, err
 	}
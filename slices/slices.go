@@ -12,6 +12,17 @@ func FirstOrPanic[T any](slice []T, matches func(item T) bool) (result T) {
 	panic("Item not found in slice")
 }
 
+// First returns the first item matching the closure. The second result is false if there are no matches.
+func First[T any](slice []T, matches func(item T) bool) (result T, found bool) {
+	for _, item := range slice {
+		if matches(item) {
+			return item, true
+		}
+	}
+
+	return result, false
+}
+
 // Contains returns true if any of the elements match using the supplied function.
 func Contains[T any](slice []T, matches func(item T) bool) bool {
 	for _, item := range slice {